@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/client"
+	"polymarket-cli/internal/output"
+)
+
+var (
+	activityMarket        []string
+	activityEventID       []int
+	activityTypes         []string
+	activitySide          string
+	activityStart         string
+	activityEnd           string
+	activityLimit         int
+	activityOffset        int
+	activitySortBy        string
+	activitySortDirection string
+	allActivity           bool
+)
+
+// activityTypeNames are the activity types the data API filters on.
+var activityTypeNames = []string{"TRADE", "SPLIT", "MERGE", "REDEEM", "REWARD", "CONVERSION"}
+
+var activityCmd = &cobra.Command{
+	Use:   "activity [user-address]",
+	Short: "Get on-chain activity for a user",
+	Long: `Returns trades, splits, merges, redemptions, rewards and conversions for a
+user, newest first by default.
+
+When no user address is given, the Safe derived from the configured signer
+is used. --start and --end take a Unix timestamp, a date (2006-01-02) or an
+RFC 3339 time.
+
+With --all, pages of up to 500 entries are fetched from --offset until the
+feed is exhausted and streamed to the output as they arrive. The API does
+not accept offsets above 10000; results beyond it are cut off with a
+warning.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userAddr, err := userAddress(args)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		params, err := activityParamsFromFlags()
+		if err != nil {
+			printError(usageError{err})
+			return
+		}
+
+		if allActivity {
+			streamAllActivity(cmd.Context(), userAddr, params)
+			return
+		}
+
+		activity, err := fetchActivity(cmd.Context(), userAddr, params)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if err := render(activity, activityOutput); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(activityCmd)
+
+	activityCmd.Flags().StringSliceVar(&activityMarket, "market", []string{}, "Comma-separated list of condition IDs")
+	activityCmd.Flags().IntSliceVar(&activityEventID, "event-id", []int{}, "Comma-separated list of event IDs")
+	activityCmd.Flags().StringSliceVar(&activityTypes, "type", []string{}, "Comma-separated activity types (TRADE, SPLIT, MERGE, REDEEM, REWARD, CONVERSION)")
+	activityCmd.Flags().StringVar(&activitySide, "side", "", "Filter trades by side (BUY, SELL)")
+	activityCmd.Flags().StringVar(&activityStart, "start", "", "Only activity at or after this time")
+	activityCmd.Flags().StringVar(&activityEnd, "end", "", "Only activity at or before this time")
+	activityCmd.Flags().IntVar(&activityLimit, "limit", 100, "Limit results (0-500)")
+	activityCmd.Flags().IntVar(&activityOffset, "offset", 0, "Offset for pagination (0-10000)")
+	activityCmd.Flags().StringVar(&activitySortBy, "sort-by", "TIMESTAMP", "Sort by (TIMESTAMP, TOKENS, CASH)")
+	activityCmd.Flags().StringVar(&activitySortDirection, "sort-direction", "DESC", "Sort direction (ASC, DESC)")
+	activityCmd.Flags().BoolVar(&allActivity, "all", false, "Fetch every page of activity (ignores --limit)")
+}
+
+type Activity struct {
+	ProxyWallet     string  `json:"proxyWallet"`
+	Timestamp       int64   `json:"timestamp"`
+	ConditionID     string  `json:"conditionId"`
+	Type            string  `json:"type"`
+	Size            float64 `json:"size"`
+	UsdcSize        float64 `json:"usdcSize"`
+	TransactionHash string  `json:"transactionHash"`
+	Price           float64 `json:"price"`
+	Asset           string  `json:"asset"`
+	Side            string  `json:"side"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	Title           string  `json:"title"`
+	Slug            string  `json:"slug"`
+	Icon            string  `json:"icon"`
+	EventSlug       string  `json:"eventSlug"`
+	Outcome         string  `json:"outcome"`
+	Name            string  `json:"name"`
+	Pseudonym       string  `json:"pseudonym"`
+}
+
+type activityParams struct {
+	Market        []string
+	EventID       []int
+	Types         []string
+	Side          string
+	Start         int64
+	End           int64
+	Limit         int
+	Offset        int
+	SortBy        string
+	SortDirection string
+}
+
+// activityOutput sets the default table columns and number formats for
+// activity.
+var activityOutput = output.Options{
+	TableColumns: []string{"timestamp", "type", "side", "title", "outcome", "size", "price", "usdcSize"},
+	Number: map[string]string{
+		"size":     "%.2f",
+		"price":    "%.3f",
+		"usdcSize": "%.2f",
+	},
+}
+
+const (
+	maxActivityLimit  = 500
+	maxActivityOffset = 10000
+)
+
+func activityParamsFromFlags() (activityParams, error) {
+	params := activityParams{
+		Market:        activityMarket,
+		EventID:       activityEventID,
+		Side:          strings.ToUpper(activitySide),
+		Limit:         activityLimit,
+		Offset:        activityOffset,
+		SortBy:        activitySortBy,
+		SortDirection: activitySortDirection,
+	}
+
+	for _, t := range activityTypes {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if !slices.Contains(activityTypeNames, t) {
+			return activityParams{}, fmt.Errorf("unknown activity type %q (expected %s)", t, strings.Join(activityTypeNames, ", "))
+		}
+		params.Types = append(params.Types, t)
+	}
+
+	if params.Side != "" && params.Side != "BUY" && params.Side != "SELL" {
+		return activityParams{}, fmt.Errorf("invalid side %q (expected BUY or SELL)", activitySide)
+	}
+
+	var err error
+	if params.Start, err = parseTimestamp(activityStart); err != nil {
+		return activityParams{}, fmt.Errorf("invalid --start: %w", err)
+	}
+	if params.End, err = parseTimestamp(activityEnd); err != nil {
+		return activityParams{}, fmt.Errorf("invalid --end: %w", err)
+	}
+	if params.Start > 0 && params.End > 0 && params.End < params.Start {
+		return activityParams{}, fmt.Errorf("--end is before --start")
+	}
+
+	return params, nil
+}
+
+// parseTimestamp parses a Unix timestamp, a date or an RFC 3339 time into
+// Unix seconds. An empty string yields 0.
+func parseTimestamp(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ts, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Unix(), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%q is not a Unix timestamp, date (2006-01-02) or RFC 3339 time", s)
+}
+
+func fetchActivity(ctx context.Context, userAddr string, params activityParams) ([]Activity, error) {
+	httpClient := newDataAPIClient()
+
+	query := url.Values{}
+	query.Set("user", userAddr)
+
+	for _, m := range params.Market {
+		query.Add("market", m)
+	}
+
+	for _, id := range params.EventID {
+		query.Add("eventId", fmt.Sprintf("%d", id))
+	}
+
+	for _, t := range params.Types {
+		query.Add("type", t)
+	}
+
+	if params.Side != "" {
+		query.Set("side", params.Side)
+	}
+
+	if params.Start > 0 {
+		query.Set("start", fmt.Sprintf("%d", params.Start))
+	}
+
+	if params.End > 0 {
+		query.Set("end", fmt.Sprintf("%d", params.End))
+	}
+
+	query.Set("limit", fmt.Sprintf("%d", params.Limit))
+	query.Set("offset", fmt.Sprintf("%d", params.Offset))
+
+	if params.SortBy != "" {
+		query.Set("sortBy", params.SortBy)
+	}
+
+	if params.SortDirection != "" {
+		query.Set("sortDirection", params.SortDirection)
+	}
+
+	var activity []Activity
+	if err := httpClient.GetJSONWithMultipleValuesContext(ctx, "/activity", query, &activity); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// activityPaginator pages through /activity from params.Offset.
+func activityPaginator(userAddr string, params activityParams) *client.Paginator[Activity] {
+	fetch := func(ctx context.Context, offset, limit int) ([]Activity, error) {
+		params.Offset = offset
+		params.Limit = limit
+		return fetchActivity(ctx, userAddr, params)
+	}
+
+	return client.NewPaginator(fetch, client.PageOptions{
+		Offset:    params.Offset,
+		Limit:     maxActivityLimit,
+		MaxOffset: maxActivityOffset,
+	})
+}
+
+// streamAllActivity prints every activity entry, writing each page as soon
+// as it is fetched where the output format allows.
+func streamAllActivity(ctx context.Context, userAddr string, params activityParams) {
+	paginator := activityPaginator(userAddr, params)
+
+	count, err := streamPages(ctx, paginator, activityOutput)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if paginator.Truncated() {
+		fmt.Fprintf(os.Stderr, "Warning: stopped at the API offset cap of %d after %d entries; more may exist. Narrow the query with --start, --end, --type or --market.\n", maxActivityOffset, count)
+	}
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/chain"
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/relayer"
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+var approvalsAddress string
+
+type approvalTarget struct {
+	Name    string
+	Address common.Address
+}
+
+// usdcSpenders are the contracts that pull USDC from the wallet when
+// splitting or trading.
+func usdcSpenders() []approvalTarget {
+	return []approvalTarget{
+		{Name: "ConditionalTokens", Address: activeNetwork.CTF},
+		{Name: "Exchange", Address: activeNetwork.Exchange},
+		{Name: "NegRiskExchange", Address: activeNetwork.NegRiskExchange},
+		{Name: "NegRiskAdapter", Address: activeNetwork.NegRiskAdapter},
+	}
+}
+
+// ctfOperators are the contracts that move outcome tokens on the wallet's
+// behalf.
+func ctfOperators() []approvalTarget {
+	return []approvalTarget{
+		{Name: "Exchange", Address: activeNetwork.Exchange},
+		{Name: "NegRiskExchange", Address: activeNetwork.NegRiskExchange},
+		{Name: "NegRiskAdapter", Address: activeNetwork.NegRiskAdapter},
+	}
+}
+
+type ApprovalStatus struct {
+	Token     string `json:"token"`
+	Spender   string `json:"spender"`
+	Address   string `json:"address"`
+	Allowance string `json:"allowance,omitempty"`
+	Approved  bool   `json:"approved"`
+}
+
+var approvalsCmd = &cobra.Command{
+	Use:   "approvals",
+	Short: "Manage USDC and outcome token approvals",
+	Long: `Inspect and manage the USDC and Conditional Tokens approvals that the
+Exchange, NegRiskExchange and NegRiskAdapter need before the wallet can trade,
+split or merge.`,
+}
+
+var approvalsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show current approvals for the wallet",
+	Long:  `Reads the wallet's current allowances over the RPC endpoint configured as rpc_url.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if config.AppCfg.RPCURL == "" {
+			printError(errors.New("rpc_url is required in config"))
+			return
+		}
+
+		wallet, err := approvalsWallet()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		statuses, err := fetchApprovalStatus(cmd.Context(), wallet)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if err := printResult("Approvals for "+wallet.Hex(), statuses); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var approvalsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Grant all trading approvals",
+	Long:  `Approves unlimited USDC and all outcome tokens for every Polymarket contract in a single multisend.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runApprovals(cmd.Context(), true)
+	},
+}
+
+var approvalsRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke all trading approvals",
+	Long:  `Resets USDC allowances to zero and revokes outcome token approvals for every Polymarket contract in a single multisend.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runApprovals(cmd.Context(), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(approvalsCmd)
+	approvalsCmd.AddCommand(approvalsStatusCmd)
+	approvalsCmd.AddCommand(approvalsSetCmd)
+	approvalsCmd.AddCommand(approvalsRevokeCmd)
+
+	approvalsStatusCmd.Flags().StringVar(&approvalsAddress, "address", "", "Wallet address to inspect (default: wallet derived from the configured signer)")
+	approvalsStatusCmd.Flags().StringVar(&txType, "tx-type", "", "Transaction type (SAFE or PROXY; default: tx_type from config, else SAFE)")
+
+	for _, c := range []*cobra.Command{approvalsSetCmd, approvalsRevokeCmd} {
+		c.Flags().StringVar(&txType, "tx-type", "", "Transaction type (SAFE or PROXY; default: tx_type from config, else SAFE)")
+		addSigningFlags(c)
+	}
+}
+
+func approvalsWallet() (common.Address, error) {
+	if approvalsAddress != "" {
+		if !common.IsHexAddress(approvalsAddress) {
+			return common.Address{}, usageError{fmt.Errorf("invalid address: %s", approvalsAddress)}
+		}
+		return common.HexToAddress(approvalsAddress), nil
+	}
+
+	owner, err := signerAddress()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w (or pass --address)", err)
+	}
+
+	walletTxType := selectedTxType()
+	if walletTxType == relayer.RelayerTxTypePROXY && !activeNetwork.SupportsProxy() {
+		return common.Address{}, fmt.Errorf("%w: %s", relayer.ErrProxyUnsupported, activeNetwork.Name)
+	}
+
+	return activeNetwork.DeriveWallet(owner, walletTxType), nil
+}
+
+func fetchApprovalStatus(ctx context.Context, wallet common.Address) ([]ApprovalStatus, error) {
+	chainClient, err := chain.NewClient(config.AppCfg.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	defer chainClient.Close()
+
+	var statuses []ApprovalStatus
+	for _, spender := range usdcSpenders() {
+		allowance, err := chainClient.Allowance(ctx, activeNetwork.USDC, wallet, spender.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read USDC allowance for %s: %w", spender.Name, err)
+		}
+
+		statuses = append(statuses, ApprovalStatus{
+			Token:     "USDC",
+			Spender:   spender.Name,
+			Address:   spender.Address.Hex(),
+			Allowance: formatBaseUnits(allowance),
+			Approved:  allowance.Sign() > 0,
+		})
+	}
+
+	for _, operator := range ctfOperators() {
+		approved, err := chainClient.IsApprovedForAll(ctx, activeNetwork.CTF, wallet, operator.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CTF approval for %s: %w", operator.Name, err)
+		}
+
+		statuses = append(statuses, ApprovalStatus{
+			Token:    "CTF",
+			Spender:  operator.Name,
+			Address:  operator.Address.Hex(),
+			Approved: approved,
+		})
+	}
+
+	return statuses, nil
+}
+
+func buildApprovalTransactions(approve bool) ([]*transactions.Transaction, error) {
+	allowance := big.NewInt(0)
+	if approve {
+		allowance = math.MaxBig256
+	}
+
+	var txs []*transactions.Transaction
+	for _, spender := range usdcSpenders() {
+		tx, err := transactions.BuildApproveTransaction(transactions.ApproveParams{
+			Token:   activeNetwork.USDC,
+			Spender: spender.Address,
+			Amount:  allowance,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build transaction: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	for _, operator := range ctfOperators() {
+		tx, err := transactions.BuildSetApprovalForAllTransaction(transactions.SetApprovalForAllParams{
+			Token:    activeNetwork.CTF,
+			Operator: operator.Address,
+			Approved: approve,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build transaction: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+func runApprovals(ctx context.Context, approve bool) {
+	if !hasSigner() {
+		printError(errNoSigner)
+		return
+	}
+
+	if submits() && len(config.AppCfg.Builder.APIKey) == 0 {
+		printError(errNoBuilderKey)
+		return
+	}
+
+	client, err := newRelayerClient(selectedTxType())
+	if err != nil {
+		printError(err)
+		return
+	}
+	defer client.Close()
+
+	txs, err := buildApprovalTransactions(approve)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	metadata := "Set approvals"
+	if !approve {
+		metadata = "Revoke approvals"
+	}
+
+	result, err := submitTransactions(ctx, client, txs, metadata)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if result != nil && wait {
+		waitForTransaction(ctx, client, result.TransactionID)
+	}
+}
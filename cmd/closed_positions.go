@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/client"
+	"polymarket-cli/internal/output"
+)
+
+var (
+	closedMarket        []string
+	closedEventID       []int
+	closedTitle         string
+	closedLimit         int
+	closedOffset        int
+	closedSortBy        string
+	closedSortDirection string
+	closedSummary       string
+	allClosedPositions  bool
+)
+
+var closedPositionsCmd = &cobra.Command{
+	Use:   "closed-positions [user-address]",
+	Short: "Get closed positions and realized PnL for a user",
+	Long: `Returns positions that were sold, merged or redeemed, with the PnL realized
+on each.
+
+When no user address is given, the Safe derived from the configured signer
+is used.
+
+With --summary event or --summary month, every closed position matching the
+filters is fetched and the realized PnL is totalled per event or per
+calendar month (UTC) of closing.
+
+With --all, pages of up to 50 positions are fetched from --offset until the
+list is exhausted and streamed to the output as they arrive.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userAddr, err := userAddress(args)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if closedSummary != "" && closedSummary != "event" && closedSummary != "month" {
+			printError(usageError{fmt.Errorf("invalid --summary %q (expected event or month)", closedSummary)})
+			return
+		}
+
+		params := closedPositionsParams{
+			Market:        closedMarket,
+			EventID:       closedEventID,
+			Title:         closedTitle,
+			Limit:         closedLimit,
+			Offset:        closedOffset,
+			SortBy:        closedSortBy,
+			SortDirection: closedSortDirection,
+		}
+
+		if closedSummary != "" {
+			positions, err := fetchAllClosedPositions(cmd.Context(), userAddr, params)
+			if err != nil {
+				printError(err)
+				return
+			}
+
+			if err := render(summarizeRealizedPnl(positions, closedSummary), pnlSummaryOutput); err != nil {
+				printError(err)
+			}
+			return
+		}
+
+		if allClosedPositions {
+			streamAllClosedPositions(cmd.Context(), userAddr, params)
+			return
+		}
+
+		positions, err := fetchClosedPositions(cmd.Context(), userAddr, params)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if err := render(positions, closedPositionsOutput); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(closedPositionsCmd)
+
+	closedPositionsCmd.Flags().StringSliceVar(&closedMarket, "market", []string{}, "Comma-separated list of condition IDs")
+	closedPositionsCmd.Flags().IntSliceVar(&closedEventID, "event-id", []int{}, "Comma-separated list of event IDs")
+	closedPositionsCmd.Flags().StringVar(&closedTitle, "title", "", "Filter by title")
+	closedPositionsCmd.Flags().IntVar(&closedLimit, "limit", 50, "Limit results (0-50)")
+	closedPositionsCmd.Flags().IntVar(&closedOffset, "offset", 0, "Offset for pagination (0-100000)")
+	closedPositionsCmd.Flags().StringVar(&closedSortBy, "sort-by", "REALIZEDPNL", "Sort by (REALIZEDPNL, TITLE, PRICE, AVGPRICE, TIMESTAMP)")
+	closedPositionsCmd.Flags().StringVar(&closedSortDirection, "sort-direction", "DESC", "Sort direction (ASC, DESC)")
+	closedPositionsCmd.Flags().StringVar(&closedSummary, "summary", "", "Total realized PnL per event or month instead of listing positions")
+	closedPositionsCmd.Flags().BoolVar(&allClosedPositions, "all", false, "Fetch every page of closed positions (ignores --limit)")
+}
+
+type ClosedPosition struct {
+	ProxyWallet     string  `json:"proxyWallet"`
+	Asset           string  `json:"asset"`
+	ConditionID     string  `json:"conditionId"`
+	AvgPrice        float64 `json:"avgPrice"`
+	TotalBought     float64 `json:"totalBought"`
+	RealizedPnl     float64 `json:"realizedPnl"`
+	CurPrice        float64 `json:"curPrice"`
+	Timestamp       int64   `json:"timestamp"`
+	Title           string  `json:"title"`
+	Slug            string  `json:"slug"`
+	Icon            string  `json:"icon"`
+	EventSlug       string  `json:"eventSlug"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	OppositeOutcome string  `json:"oppositeOutcome"`
+	OppositeAsset   string  `json:"oppositeAsset"`
+	EndDate         string  `json:"endDate"`
+}
+
+// Time returns when the position was closed.
+func (p ClosedPosition) Time() time.Time {
+	return time.Unix(p.Timestamp, 0).UTC()
+}
+
+// PnlSummary totals realized PnL over the closed positions of one event or
+// month.
+type PnlSummary struct {
+	Group       string  `json:"group"`
+	Positions   int     `json:"positions"`
+	Won         int     `json:"won"`
+	Lost        int     `json:"lost"`
+	TotalBought float64 `json:"totalBought"`
+	RealizedPnl float64 `json:"realizedPnl"`
+}
+
+type closedPositionsParams struct {
+	Market        []string
+	EventID       []int
+	Title         string
+	Limit         int
+	Offset        int
+	SortBy        string
+	SortDirection string
+}
+
+// closedPositionsOutput sets the default table columns and number formats
+// for closed positions.
+var closedPositionsOutput = output.Options{
+	TableColumns: []string{"timestamp", "title", "outcome", "avgPrice", "curPrice", "totalBought", "realizedPnl"},
+	Number: map[string]string{
+		"avgPrice":    "%.3f",
+		"curPrice":    "%.3f",
+		"totalBought": "%.2f",
+		"realizedPnl": "%+.2f",
+	},
+}
+
+// pnlSummaryOutput sets the number formats for --summary.
+var pnlSummaryOutput = output.Options{
+	Number: map[string]string{
+		"totalBought": "%.2f",
+		"realizedPnl": "%+.2f",
+	},
+}
+
+const (
+	maxClosedPositionsLimit  = 50
+	maxClosedPositionsOffset = 100000
+)
+
+func fetchClosedPositions(ctx context.Context, userAddr string, params closedPositionsParams) ([]ClosedPosition, error) {
+	httpClient := newDataAPIClient()
+
+	query := url.Values{}
+	query.Set("user", userAddr)
+
+	for _, m := range params.Market {
+		query.Add("market", m)
+	}
+
+	for _, id := range params.EventID {
+		query.Add("eventId", fmt.Sprintf("%d", id))
+	}
+
+	if params.Title != "" {
+		query.Set("title", params.Title)
+	}
+
+	query.Set("limit", fmt.Sprintf("%d", params.Limit))
+	query.Set("offset", fmt.Sprintf("%d", params.Offset))
+
+	if params.SortBy != "" {
+		query.Set("sortBy", params.SortBy)
+	}
+
+	if params.SortDirection != "" {
+		query.Set("sortDirection", params.SortDirection)
+	}
+
+	var positions []ClosedPosition
+	if err := httpClient.GetJSONWithMultipleValuesContext(ctx, "/closed-positions", query, &positions); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// closedPositionsPaginator pages through /closed-positions from
+// params.Offset.
+func closedPositionsPaginator(userAddr string, params closedPositionsParams) *client.Paginator[ClosedPosition] {
+	fetch := func(ctx context.Context, offset, limit int) ([]ClosedPosition, error) {
+		params.Offset = offset
+		params.Limit = limit
+		return fetchClosedPositions(ctx, userAddr, params)
+	}
+
+	return client.NewPaginator(fetch, client.PageOptions{
+		Offset:    params.Offset,
+		Limit:     maxClosedPositionsLimit,
+		MaxOffset: maxClosedPositionsOffset,
+	})
+}
+
+// fetchAllClosedPositions returns every closed position from params.Offset.
+// It fails rather than return a partial list when the offset cap is reached.
+func fetchAllClosedPositions(ctx context.Context, userAddr string, params closedPositionsParams) ([]ClosedPosition, error) {
+	paginator := closedPositionsPaginator(userAddr, params)
+
+	positions, err := paginator.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if paginator.Truncated() {
+		return nil, fmt.Errorf("more than %d closed positions match; narrow the query", maxClosedPositionsOffset+maxClosedPositionsLimit)
+	}
+
+	return positions, nil
+}
+
+// streamAllClosedPositions prints every closed position, writing each page
+// as soon as it is fetched where the output format allows.
+func streamAllClosedPositions(ctx context.Context, userAddr string, params closedPositionsParams) {
+	paginator := closedPositionsPaginator(userAddr, params)
+
+	count, err := streamPages(ctx, paginator, closedPositionsOutput)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if paginator.Truncated() {
+		fmt.Fprintf(os.Stderr, "Warning: stopped at the API offset cap of %d after %d closed positions; more may exist. Narrow the query with --market, --event-id or --title.\n", maxClosedPositionsOffset, count)
+	}
+}
+
+// summarizeRealizedPnl totals positions per event slug or per closing month
+// ("2006-01"), ordered by group.
+func summarizeRealizedPnl(positions []ClosedPosition, by string) []PnlSummary {
+	index := make(map[string]int)
+	summaries := []PnlSummary{}
+
+	for _, position := range positions {
+		group := position.EventSlug
+		if by == "month" {
+			group = position.Time().Format("2006-01")
+		}
+
+		i, ok := index[group]
+		if !ok {
+			i = len(summaries)
+			index[group] = i
+			summaries = append(summaries, PnlSummary{Group: group})
+		}
+
+		summary := &summaries[i]
+		summary.Positions++
+		summary.TotalBought += position.TotalBought
+		summary.RealizedPnl += position.RealizedPnl
+		switch {
+		case position.RealizedPnl > 0:
+			summary.Won++
+		case position.RealizedPnl < 0:
+			summary.Lost++
+		}
+	}
+
+	slices.SortFunc(summaries, func(a, b PnlSummary) int {
+		return cmp.Compare(a.Group, b.Group)
+	})
+
+	return summaries
+}
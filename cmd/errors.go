@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/apierror"
+)
+
+// Exit codes returned by the CLI.
+const (
+	ExitOK            = 0
+	ExitError         = 1
+	ExitUsage         = 2
+	ExitAuth          = 3
+	ExitRateLimited   = 4
+	ExitNonceConflict = 5
+	ExitAPI           = 6
+	ExitInterrupted   = 130
+)
+
+var errNoBuilderKey = errors.New("builder API key not configured")
+
+// commandErr is the first error reported by the running command. Commands
+// print their own errors, so it only determines the exit code.
+var commandErr error
+
+// usageError marks invalid command-line usage: errors from cobra itself,
+// such as unknown flags, and flag values a command rejects.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// printError prints err with a hint on how to resolve it and records it for
+// the exit code.
+func printError(err error) {
+	infof("Error: %v\n", err)
+	if hint := errorHint(err); hint != "" {
+		infof("Hint: %s\n", hint)
+	}
+
+	if commandErr == nil {
+		commandErr = err
+	}
+}
+
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "interrupted; a request that was already submitted may still be executed by the relayer"
+	case apierror.IsAuthError(err):
+		hint := "check builder.api_key, builder.api_secret and builder.passphrase"
+		if config.AppCfg != nil && config.AppCfg.Profile != "" {
+			hint += fmt.Sprintf(" in profile %q", config.AppCfg.Profile)
+		}
+		return hint
+	case apierror.IsRateLimited(err):
+		apiErr, _ := apierror.As(err)
+		if apiErr.RetryAfter > 0 {
+			return fmt.Sprintf("rate limited; retry in %s", apiErr.RetryAfter)
+		}
+		return "rate limited; wait a moment and retry"
+	case apierror.IsNonceConflict(err):
+		return "the relayer nonce changed, likely because another request for this wallet went through; rerun the command (when signing offline, pass a fresh --nonce)"
+	}
+
+	return ""
+}
+
+// ExitCode maps an error returned by Execute to the process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageError{}):
+		return ExitUsage
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case apierror.IsAuthError(err):
+		return ExitAuth
+	case apierror.IsRateLimited(err):
+		return ExitRateLimited
+	case apierror.IsNonceConflict(err):
+		return ExitNonceConflict
+	}
+
+	if _, ok := apierror.As(err); ok {
+		return ExitAPI
+	}
+
+	return ExitError
+}
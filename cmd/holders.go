@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/output"
+)
+
+var (
+	holdersLimit      int
+	holdersMinBalance float64
+)
+
+var holdersCmd = &cobra.Command{
+	Use:   "holders <condition-id>...",
+	Short: "List the top holders of one or more markets",
+	Long: `Returns the largest holders of each outcome token of the given conditions,
+one row per holder, largest first within each token.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printError(usageError{errors.New("condition ID is required")})
+			return
+		}
+
+		for _, arg := range args {
+			if _, err := hexutil.Decode(arg); err != nil {
+				printError(usageError{fmt.Errorf("invalid condition ID %q: %w", arg, err)})
+				return
+			}
+		}
+
+		var rows []HolderRow
+		for _, conditionID := range args {
+			holders, err := fetchHolders(cmd.Context(), conditionID, holdersLimit, holdersMinBalance)
+			if err != nil {
+				printError(err)
+				return
+			}
+			rows = append(rows, holderRows(conditionID, holders)...)
+		}
+
+		if err := render(rows, holdersOutput); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(holdersCmd)
+
+	holdersCmd.Flags().IntVar(&holdersLimit, "limit", 20, "Maximum holders per outcome token (0-20)")
+	holdersCmd.Flags().Float64Var(&holdersMinBalance, "min-balance", 1, "Minimum token balance")
+}
+
+type Holder struct {
+	ProxyWallet  string  `json:"proxyWallet"`
+	Asset        string  `json:"asset"`
+	Pseudonym    string  `json:"pseudonym"`
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+}
+
+// TokenHolders is the /holders response for one outcome token.
+type TokenHolders struct {
+	Token   string   `json:"token"`
+	Holders []Holder `json:"holders"`
+}
+
+// HolderRow is a holder flattened with the condition it was queried for.
+type HolderRow struct {
+	ConditionID string `json:"conditionId"`
+	Holder
+}
+
+// holdersOutput sets the default table columns and number formats for
+// holders.
+var holdersOutput = output.Options{
+	TableColumns: []string{"conditionId", "outcomeIndex", "proxyWallet", "pseudonym", "amount"},
+	Number: map[string]string{
+		"amount": "%.2f",
+	},
+}
+
+func fetchHolders(ctx context.Context, conditionID string, limit int, minBalance float64) ([]TokenHolders, error) {
+	httpClient := newDataAPIClient()
+
+	query := url.Values{}
+	query.Set("market", conditionID)
+	query.Set("limit", fmt.Sprintf("%d", limit))
+	query.Set("minBalance", fmt.Sprintf("%g", minBalance))
+
+	var holders []TokenHolders
+	if err := httpClient.GetJSONWithMultipleValuesContext(ctx, "/holders", query, &holders); err != nil {
+		return nil, err
+	}
+
+	return holders, nil
+}
+
+func holderRows(conditionID string, tokens []TokenHolders) []HolderRow {
+	var rows []HolderRow
+	for _, token := range tokens {
+		for _, holder := range token.Holders {
+			if holder.Asset == "" {
+				holder.Asset = token.Token
+			}
+			rows = append(rows, HolderRow{ConditionID: conditionID, Holder: holder})
+		}
+	}
+	return rows
+}
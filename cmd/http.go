@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"net/http"
+	"sync"
+
+	"polymarket-cli/internal/client"
+	"polymarket-cli/internal/config"
+)
+
+var (
+	sharedHTTPClientOnce sync.Once
+	sharedHTTPClient     *http.Client
+)
+
+// apiHTTPClient returns the client shared by the data API and relayer clients,
+// so retries and the per-host rate limit from the http config block apply to
+// every request the command makes.
+func apiHTTPClient() *http.Client {
+	sharedHTTPClientOnce.Do(func() {
+		cfg := config.AppCfg.HTTP
+		sharedHTTPClient = client.NewRetryingClient(client.Policy{
+			Timeout:    cfg.Timeout,
+			MaxRetries: cfg.MaxRetries,
+			MinBackoff: cfg.MinBackoff,
+			MaxBackoff: cfg.MaxBackoff,
+			RateLimit:  cfg.RateLimit,
+			Burst:      cfg.Burst,
+		})
+	})
+
+	return sharedHTTPClient
+}
+
+// newDataAPIClient returns a client for the configured data API.
+func newDataAPIClient() *client.HTTPClient {
+	return client.NewHTTPClient(config.AppCfg.DataAPIBaseURL, client.WithHTTPClient(apiHTTPClient()))
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/internal/keys"
+	"polymarket-cli/internal/output"
+)
+
+var keyFromConfig bool
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage encrypted signing keys",
+	Long: `Manages go-ethereum v3 keystore files in keystore_dir (default ~/.polymarket-cli/keystore).
+Set "key" in config to a key name or address to sign with it; the passphrase is
+prompted for, or read from --passphrase-fd or ` + passphraseEnv + `.`,
+}
+
+var keysNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Generate a new encrypted key",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			printError(fmt.Errorf("failed to generate key: %w", err))
+			return
+		}
+
+		storeKey(args[0], privateKey)
+	},
+}
+
+var keysImportCmd = &cobra.Command{
+	Use:   "import <name>",
+	Short: "Import a hex private key into the keystore",
+	Long: `Encrypts a hex private key and stores it under <name>. The key is prompted for on a
+terminal or read from stdin; --from-config imports private_key from the config file instead.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hexKey, err := readImportKey()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			printError(fmt.Errorf("invalid private key: %w", err))
+			return
+		}
+
+		storeKey(args[0], privateKey)
+	},
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List keys in the keystore",
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := keys.NewStore(config.AppCfg.KeystoreDir).List()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if list == nil {
+			list = []keys.Key{}
+		}
+
+		if err := render(list, output.Options{}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var keysExportAddressCmd = &cobra.Command{
+	Use:   "export-address <name-or-address>",
+	Short: "Print the address of a stored key",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, err := keys.NewStore(config.AppCfg.KeystoreDir).Find(args[0])
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		fmt.Println(key.Address)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+	keysCmd.AddCommand(keysNewCmd)
+	keysCmd.AddCommand(keysImportCmd)
+	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysExportAddressCmd)
+
+	keysImportCmd.Flags().BoolVar(&keyFromConfig, "from-config", false, "Import private_key from the config file")
+}
+
+func storeKey(name string, privateKey *ecdsa.PrivateKey) {
+	passphrase, err := readNewPassphrase()
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	key, err := keys.NewStore(config.AppCfg.KeystoreDir).Create(name, privateKey, passphrase)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if err := render(key, output.Options{}); err != nil {
+		printError(err)
+	}
+}
+
+func readImportKey() (string, error) {
+	if keyFromConfig {
+		if config.AppCfg.PrivateKey == "" {
+			return "", errors.New("private_key not set in config")
+		}
+		return config.AppCfg.PrivateKey, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Private key (hex): ")
+		secret, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read private key: %w", err)
+		}
+		return strings.TrimSpace(string(secret)), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read private key from stdin: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
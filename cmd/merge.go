@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/relayer"
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+var amount string
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [condition-id]",
+	Short: "Merge complementary outcome tokens back to USDC",
+	Long: `Merge equal amounts of YES and NO tokens for a condition back into USDC.
+
+The amount defaults to the smaller of the wallet's YES and NO balances,
+read on chain when rpc_url is configured and otherwise taken from the data
+API rounded down to whole base units. Neg-risk markets are merged through the
+NegRiskAdapter; they are detected from the positions data or forced with
+--neg-risk.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printError(usageError{errors.New("condition ID is required")})
+			return
+		}
+
+		conditionID, err := hexutil.Decode(args[0])
+		if err != nil {
+			printError(usageError{fmt.Errorf("invalid condition ID: %w", err)})
+			return
+		}
+
+		if amount != "" {
+			if _, err := parseBaseUnits(amount); err != nil {
+				printError(usageError{err})
+				return
+			}
+		}
+
+		if !hasSigner() {
+			printError(errNoSigner)
+			return
+		}
+
+		if submits() && len(config.AppCfg.Builder.APIKey) == 0 {
+			printError(errNoBuilderKey)
+			return
+		}
+
+		client, err := newRelayerClient(selectedTxType())
+		if err != nil {
+			printError(err)
+			return
+		}
+		defer client.Close()
+
+		txs, err := mergeTransactions(cmd.Context(), client, common.BytesToHash(conditionID))
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		result, err := submitTransactions(cmd.Context(), client, txs, "Merge positions")
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if result != nil && wait {
+			waitForTransaction(cmd.Context(), client, result.TransactionID)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().StringVar(&txType, "tx-type", "", "Transaction type (SAFE or PROXY; default: tx_type from config, else SAFE)")
+	mergeCmd.Flags().StringVar(&amount, "amount", "", "Number of full sets to merge (default: min of YES/NO balances)")
+	mergeCmd.Flags().BoolVar(&negRisk, "neg-risk", false, "Merge through the NegRiskAdapter (neg-risk market)")
+	addSigningFlags(mergeCmd)
+}
+
+// mergeTransactions builds the merge call for a condition. When signing
+// offline with an explicit --amount the data API is not consulted.
+func mergeTransactions(ctx context.Context, client *relayer.Client, conditionID common.Hash) ([]*transactions.Transaction, error) {
+	var positions []Position
+	if !offline() || amount == "" {
+		var err error
+		positions, err = fetchConditionPositions(ctx, client.WalletAddress().Hex(), conditionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch positions: %w", err)
+		}
+	}
+
+	mergeAmount, err := resolveMergeAmount(ctx, client.WalletAddress(), positions)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := buildMergeTransaction(conditionID, mergeAmount, negRisk || isNegRisk(positions))
+	if err != nil {
+		return nil, err
+	}
+
+	infof("Merging %s sets for condition %s\n", formatBaseUnits(mergeAmount), conditionID.Hex())
+
+	return []*transactions.Transaction{tx}, nil
+}
+
+// resolveMergeAmount returns the --amount flag in base units, or the number
+// of complete YES/NO sets wallet holds when the flag is not set.
+func resolveMergeAmount(ctx context.Context, wallet common.Address, positions []Position) (*big.Int, error) {
+	if amount != "" {
+		return parseBaseUnits(amount)
+	}
+
+	chainClient, err := balanceClient()
+	if err != nil {
+		return nil, err
+	}
+	if chainClient != nil {
+		defer chainClient.Close()
+	}
+
+	amounts, err := outcomeBalances(ctx, chainClient, wallet, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeable := amounts[0]
+	if amounts[1].Cmp(mergeable) < 0 {
+		mergeable = amounts[1]
+	}
+
+	if mergeable.Sign() == 0 {
+		return nil, fmt.Errorf("no complete YES/NO sets to merge")
+	}
+
+	return mergeable, nil
+}
+
+func buildMergeTransaction(conditionID common.Hash, mergeAmount *big.Int, negRisk bool) (*transactions.Transaction, error) {
+	var (
+		tx  *transactions.Transaction
+		err error
+	)
+
+	if negRisk {
+		tx, err = transactions.BuildNegRiskMergeTransaction(transactions.NegRiskMergeParams{
+			NegRiskAdapter: activeNetwork.NegRiskAdapter,
+			ConditionID:    conditionID,
+			Amount:         mergeAmount,
+		})
+	} else {
+		tx, err = transactions.BuildMergeTransaction(transactions.MergeParams{
+			ConditionalTokens:  activeNetwork.CTF,
+			CollateralToken:    activeNetwork.USDC,
+			ParentCollectionID: common.Hash{},
+			ConditionID:        conditionID,
+			Partition:          binaryPartition(),
+			Amount:             mergeAmount,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// binaryPartition is the YES/NO index set partition of a binary condition.
+func binaryPartition() []*big.Int {
+	return []*big.Int{
+		big.NewInt(1),
+		big.NewInt(2),
+	}
+}
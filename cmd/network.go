@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/relayer"
+)
+
+// activeNetwork is the network selected in config, with the relayer_url,
+// chain_id and contracts overrides applied. It is set by initConfig.
+var activeNetwork = relayer.Polygon
+
+func resolveNetwork(cfg *config.Config) (relayer.Network, error) {
+	network, err := relayer.NetworkByName(cfg.Network)
+	if err != nil {
+		return relayer.Network{}, err
+	}
+
+	if cfg.RelayerURL != "" {
+		network.RelayerURL = cfg.RelayerURL
+	}
+
+	if cfg.ChainID != 0 {
+		network.ChainID = cfg.ChainID
+	}
+
+	addresses := []struct {
+		key   string
+		value string
+		dst   *common.Address
+	}{
+		{"contracts.ctf", cfg.Contracts.CTF, &network.CTF},
+		{"contracts.usdc", cfg.Contracts.USDC, &network.USDC},
+		{"contracts.exchange", cfg.Contracts.Exchange, &network.Exchange},
+		{"contracts.neg_risk_exchange", cfg.Contracts.NegRiskExchange, &network.NegRiskExchange},
+		{"contracts.neg_risk_adapter", cfg.Contracts.NegRiskAdapter, &network.NegRiskAdapter},
+		{"contracts.safe_factory", cfg.Contracts.SafeFactory, &network.SafeFactory},
+		{"contracts.safe_multisend", cfg.Contracts.SafeMultisend, &network.SafeMultisend},
+		{"contracts.proxy_factory", cfg.Contracts.ProxyFactory, &network.ProxyFactory},
+		{"contracts.relay_hub", cfg.Contracts.RelayHub, &network.RelayHub},
+	}
+	for _, a := range addresses {
+		if a.value == "" {
+			continue
+		}
+		if !common.IsHexAddress(a.value) {
+			return relayer.Network{}, fmt.Errorf("invalid %s address: %s", a.key, a.value)
+		}
+		*a.dst = common.HexToAddress(a.value)
+	}
+
+	hashes := []struct {
+		key   string
+		value string
+		dst   *common.Hash
+	}{
+		{"contracts.safe_init_code_hash", cfg.Contracts.SafeInitCodeHash, &network.SafeInitCodeHash},
+		{"contracts.proxy_init_code_hash", cfg.Contracts.ProxyInitCodeHash, &network.ProxyInitCodeHash},
+	}
+	for _, h := range hashes {
+		if h.value == "" {
+			continue
+		}
+		hash := common.FromHex(h.value)
+		if len(hash) != common.HashLength {
+			return relayer.Network{}, fmt.Errorf("invalid %s: %s", h.key, h.value)
+		}
+		*h.dst = common.BytesToHash(hash)
+	}
+
+	return network, nil
+}
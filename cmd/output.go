@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"polymarket-cli/internal/client"
+	"polymarket-cli/internal/config"
+	"polymarket-cli/internal/output"
+)
+
+var (
+	outputFormat  string
+	outputColumns []string
+	outputSort    string
+)
+
+// structuredOutput reports whether an output format was chosen with
+// --output or output_format. Commands that print free-form progress
+// alongside their result keep their usual output unless it was, and send
+// progress to stderr when it was.
+func structuredOutput() bool {
+	return rootCmd.PersistentFlags().Changed("output") || (config.AppCfg != nil && config.AppCfg.OutputFormat != "")
+}
+
+func selectedOutputFormat() string {
+	if !rootCmd.PersistentFlags().Changed("output") && config.AppCfg != nil && config.AppCfg.OutputFormat != "" {
+		return config.AppCfg.OutputFormat
+	}
+	return outputFormat
+}
+
+func outputOptions() (output.Options, error) {
+	format, expr, err := output.ParseFormat(selectedOutputFormat())
+	if err != nil {
+		return output.Options{}, err
+	}
+
+	return output.Options{
+		Format:     format,
+		Expression: expr,
+		Columns:    outputColumns,
+		Sort:       outputSort,
+	}, nil
+}
+
+// render prints v to stdout in the selected output format. opts may carry
+// command-specific table columns and number formats.
+func render(v any, opts output.Options) error {
+	selected, err := outputOptions()
+	if err != nil {
+		return err
+	}
+
+	selected.TableColumns = opts.TableColumns
+	selected.Number = opts.Number
+
+	return output.Render(os.Stdout, v, selected)
+}
+
+// newStream is render for results written as they are fetched.
+func newStream(opts output.Options) (*output.Stream, error) {
+	selected, err := outputOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	selected.TableColumns = opts.TableColumns
+	selected.Number = opts.Number
+
+	return output.NewStream(os.Stdout, selected)
+}
+
+// streamPages writes every item from p to the output as each page arrives
+// and returns how many were written.
+func streamPages[T any](ctx context.Context, p *client.Paginator[T], opts output.Options) (int, error) {
+	stream, err := newStream(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for p.Next(ctx) {
+		for _, item := range p.Page() {
+			if err := stream.Write(item); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+
+	if err := stream.Close(); err != nil {
+		return count, err
+	}
+
+	return count, p.Err()
+}
+
+// printResult prints a command result. Without --output it keeps the
+// labelled, indented JSON the CLI has always printed; with it, only the
+// result is written, in the selected format.
+func printResult(label string, v any) error {
+	if structuredOutput() {
+		return render(v, output.Options{})
+	}
+
+	jsonData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	fmt.Printf("%s: %s\n", label, string(jsonData))
+	return nil
+}
+
+// infof prints progress messages, on stderr when stdout carries structured
+// output.
+func infof(format string, args ...any) {
+	if structuredOutput() {
+		fmt.Fprintf(os.Stderr, format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/output"
+	"polymarket-cli/pkg/relayer"
+)
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio [user-address...]",
+	Short: "Summarize the value and PnL of one or more wallets",
+	Long: `Prints, per wallet, the portfolio value reported by the data API together
+with totals over every open position: initial and current value, unrealized
+cash PnL, PnL already realized on those positions, the number of redeemable
+and mergeable positions, and the amount redeem-all would claim.
+
+When no address is given, the Safe or proxy wallet (per tx_type) derived
+from the configured signer is used, the same wallet redeem-all acts on. With
+several addresses, a final "total" row sums them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		users := args
+		if len(users) == 0 {
+			user, err := portfolioWallet()
+			if err != nil {
+				printError(err)
+				return
+			}
+			users = []string{user}
+		}
+
+		summaries := make([]PortfolioSummary, 0, len(users)+1)
+		for _, user := range users {
+			summary, err := fetchPortfolio(cmd.Context(), user)
+			if err != nil {
+				printError(err)
+				return
+			}
+			summaries = append(summaries, summary)
+		}
+
+		var result any = summaries[0]
+		if len(summaries) > 1 {
+			result = append(summaries, totalPortfolio(summaries))
+		}
+
+		if err := render(result, portfolioOutput); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(portfolioCmd)
+}
+
+// portfolioWallet returns the wallet redeem-all acts on: the Safe or proxy
+// wallet derived from the configured signer for tx_type.
+func portfolioWallet() (string, error) {
+	owner, err := signerAddress()
+	if err != nil {
+		return "", errors.New("user address is required when no signer is configured")
+	}
+
+	walletTxType := selectedTxType()
+	if walletTxType == relayer.RelayerTxTypePROXY && !activeNetwork.SupportsProxy() {
+		return "", fmt.Errorf("%w: %s", relayer.ErrProxyUnsupported, activeNetwork.Name)
+	}
+
+	return activeNetwork.DeriveWallet(owner, walletTxType).Hex(), nil
+}
+
+// PortfolioSummary totals a wallet's open positions. Value is the portfolio
+// value reported by /value; the other amounts are summed from /positions.
+type PortfolioSummary struct {
+	User         string  `json:"user"`
+	Value        float64 `json:"value"`
+	Positions    int     `json:"positions"`
+	InitialValue float64 `json:"initialValue"`
+	CurrentValue float64 `json:"currentValue"`
+	CashPnl      float64 `json:"cashPnl"`
+	RealizedPnl  float64 `json:"realizedPnl"`
+	Redeemable   int     `json:"redeemable"`
+	Mergeable    int     `json:"mergeable"`
+	// Claimable is the current value of redeemable positions, what
+	// redeem-all would pay out.
+	Claimable float64 `json:"claimable"`
+}
+
+type portfolioValue struct {
+	User  string  `json:"user"`
+	Value float64 `json:"value"`
+}
+
+// portfolioOutput sets the number formats for portfolio summaries.
+var portfolioOutput = output.Options{
+	Number: map[string]string{
+		"value":        "%.2f",
+		"initialValue": "%.2f",
+		"currentValue": "%.2f",
+		"cashPnl":      "%+.2f",
+		"realizedPnl":  "%+.2f",
+		"claimable":    "%.2f",
+	},
+}
+
+func fetchPortfolio(ctx context.Context, user string) (PortfolioSummary, error) {
+	value, err := fetchPortfolioValue(ctx, user)
+	if err != nil {
+		return PortfolioSummary{}, fmt.Errorf("failed to fetch value for %s: %w", user, err)
+	}
+
+	positions, err := fetchAllPositions(ctx, user, positionsParams{
+		SortBy:        "TOKENS",
+		SortDirection: "DESC",
+	})
+	if err != nil {
+		return PortfolioSummary{}, fmt.Errorf("failed to fetch positions for %s: %w", user, err)
+	}
+
+	summary := PortfolioSummary{User: user, Value: value}
+	for _, position := range positions {
+		summary.Positions++
+		summary.InitialValue += position.InitialValue
+		summary.CurrentValue += position.CurrentValue
+		summary.CashPnl += position.CashPnl
+		summary.RealizedPnl += position.RealizedPnl
+		if position.Redeemable {
+			summary.Redeemable++
+			summary.Claimable += position.CurrentValue
+		}
+		if position.Mergeable {
+			summary.Mergeable++
+		}
+	}
+
+	return summary, nil
+}
+
+func fetchPortfolioValue(ctx context.Context, user string) (float64, error) {
+	httpClient := newDataAPIClient()
+
+	query := url.Values{}
+	query.Set("user", user)
+
+	var values []portfolioValue
+	if err := httpClient.GetJSONWithMultipleValuesContext(ctx, "/value", query, &values); err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, v := range values {
+		total += v.Value
+	}
+
+	return total, nil
+}
+
+func totalPortfolio(summaries []PortfolioSummary) PortfolioSummary {
+	total := PortfolioSummary{User: "total"}
+	for _, s := range summaries {
+		total.Value += s.Value
+		total.Positions += s.Positions
+		total.InitialValue += s.InitialValue
+		total.CurrentValue += s.CurrentValue
+		total.CashPnl += s.CashPnl
+		total.RealizedPnl += s.RealizedPnl
+		total.Redeemable += s.Redeemable
+		total.Mergeable += s.Mergeable
+		total.Claimable += s.Claimable
+	}
+	return total
+}
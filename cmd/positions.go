@@ -1,14 +1,16 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"polymarket-cli/internal/client"
-	"polymarket-cli/internal/config"
+	"polymarket-cli/internal/output"
 )
 
 var (
@@ -22,36 +24,71 @@ var (
 	sortBy        string
 	sortDirection string
 	title         string
+	allPositions  bool
 )
 
 var positionsCmd = &cobra.Command{
 	Use:   "positions [user-address]",
 	Short: "Get current positions for a user",
-	Long:  `Returns positions filtered by user and optional filters.`,
+	Long: `Returns positions filtered by user and optional filters.
+
+When no user address is given, the Safe derived from the configured signer
+is used.
+
+With --all, pages of up to 500 positions are fetched from --offset until the
+list is exhausted and streamed to the output as they arrive. The API does not
+accept offsets above 10000; results beyond it are cut off with a warning.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 0 {
-			fmt.Println("Error: user address is required")
+		userAddr, err := userAddress(args)
+		if err != nil {
+			printError(err)
 			return
 		}
 
-		userAddr := args[0]
+		params := positionsParams{
+			Market:        market,
+			EventID:       eventID,
+			SizeThreshold: sizeThreshold,
+			Redeemable:    redeemable,
+			Mergeable:     mergeable,
+			Limit:         limit,
+			Offset:        offset,
+			SortBy:        sortBy,
+			SortDirection: sortDirection,
+			Title:         title,
+		}
 
-		positions, err := fetchPositions(userAddr)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
+		if allPositions {
+			streamAllPositions(cmd.Context(), userAddr, params)
 			return
 		}
 
-		jsonData, err := json.MarshalIndent(positions, "", "  ")
+		positions, err := fetchPositions(cmd.Context(), userAddr, params)
 		if err != nil {
-			fmt.Printf("Error formatting output: %v\n", err)
+			printError(err)
 			return
 		}
 
-		fmt.Println(string(jsonData))
+		if err := render(positions, positionsOutput); err != nil {
+			printError(err)
+		}
 	},
 }
 
+// userAddress returns the address given as the first argument, or the Safe
+// derived from the configured signer.
+func userAddress(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	owner, err := signerAddress()
+	if err != nil {
+		return "", errors.New("user address is required when no signer is configured")
+	}
+	return activeNetwork.DeriveSafe(owner).Hex(), nil
+}
+
 func init() {
 	rootCmd.AddCommand(positionsCmd)
 
@@ -65,6 +102,7 @@ func init() {
 	positionsCmd.Flags().StringVar(&sortBy, "sort-by", "TOKENS", "Sort by (CURRENT, INITIAL, TOKENS, CASHPNL, PERCENTPNL, TITLE, RESOLVING, PRICE, AVGPRICE)")
 	positionsCmd.Flags().StringVar(&sortDirection, "sort-direction", "DESC", "Sort direction (ASC, DESC)")
 	positionsCmd.Flags().StringVar(&title, "title", "", "Filter by title")
+	positionsCmd.Flags().BoolVar(&allPositions, "all", false, "Fetch every page of positions (ignores --limit)")
 }
 
 type Position struct {
@@ -95,57 +133,141 @@ type Position struct {
 	NegativeRisk       bool    `json:"negativeRisk"`
 }
 
-func fetchPositions(userAddr string) ([]Position, error) {
-	httpClient := client.NewHTTPClient(config.AppCfg.DataAPIBaseURL)
+type positionsParams struct {
+	Market        []string
+	EventID       []int
+	SizeThreshold float64
+	Redeemable    bool
+	Mergeable     bool
+	Limit         int
+	Offset        int
+	SortBy        string
+	SortDirection string
+	Title         string
+}
+
+// positionsOutput sets the default table columns and number formats for
+// positions.
+var positionsOutput = output.Options{
+	TableColumns: []string{"title", "outcome", "size", "avgPrice", "curPrice", "currentValue", "cashPnl", "percentPnl"},
+	Number: map[string]string{
+		"size":               "%.2f",
+		"avgPrice":           "%.3f",
+		"curPrice":           "%.3f",
+		"initialValue":       "%.2f",
+		"currentValue":       "%.2f",
+		"cashPnl":            "%+.2f",
+		"percentPnl":         "%+.2f%%",
+		"totalBought":        "%.2f",
+		"realizedPnl":        "%+.2f",
+		"percentRealizedPnl": "%+.2f%%",
+	},
+}
+
+const (
+	maxPositionsLimit  = 500
+	maxPositionsOffset = 10000
+)
+
+func fetchPositions(ctx context.Context, userAddr string, params positionsParams) ([]Position, error) {
+	httpClient := newDataAPIClient()
 
 	query := url.Values{}
 	query.Set("user", userAddr)
 
-	if len(market) > 0 {
-		for _, m := range market {
+	if len(params.Market) > 0 {
+		for _, m := range params.Market {
 			query.Add("market", m)
 		}
 	}
 
-	if len(eventID) > 0 {
-		for _, id := range eventID {
+	if len(params.EventID) > 0 {
+		for _, id := range params.EventID {
 			query.Add("eventId", fmt.Sprintf("%d", id))
 		}
 	}
 
-	query.Set("sizeThreshold", fmt.Sprintf("%.0f", sizeThreshold))
+	query.Set("sizeThreshold", fmt.Sprintf("%.0f", params.SizeThreshold))
 
-	if redeemable {
+	if params.Redeemable {
 		query.Set("redeemable", "true")
 	} else {
 		query.Set("redeemable", "false")
 	}
 
-	if mergeable {
+	if params.Mergeable {
 		query.Set("mergeable", "true")
 	} else {
 		query.Set("mergeable", "false")
 	}
 
-	query.Set("limit", fmt.Sprintf("%d", limit))
-	query.Set("offset", fmt.Sprintf("%d", offset))
+	query.Set("limit", fmt.Sprintf("%d", params.Limit))
+	query.Set("offset", fmt.Sprintf("%d", params.Offset))
 
-	if sortBy != "" {
-		query.Set("sortBy", sortBy)
+	if params.SortBy != "" {
+		query.Set("sortBy", params.SortBy)
 	}
 
-	if sortDirection != "" {
-		query.Set("sortDirection", sortDirection)
+	if params.SortDirection != "" {
+		query.Set("sortDirection", params.SortDirection)
 	}
 
-	if title != "" {
-		query.Set("title", title)
+	if params.Title != "" {
+		query.Set("title", params.Title)
 	}
 
 	var positions []Position
-	if err := httpClient.GetJSONWithMultipleValues("/positions", query, &positions); err != nil {
+	if err := httpClient.GetJSONWithMultipleValuesContext(ctx, "/positions", query, &positions); err != nil {
 		return nil, err
 	}
 
 	return positions, nil
 }
+
+// positionsPaginator pages through /positions from params.Offset.
+func positionsPaginator(userAddr string, params positionsParams) *client.Paginator[Position] {
+	fetch := func(ctx context.Context, offset, limit int) ([]Position, error) {
+		params.Offset = offset
+		params.Limit = limit
+		return fetchPositions(ctx, userAddr, params)
+	}
+
+	return client.NewPaginator(fetch, client.PageOptions{
+		Offset:    params.Offset,
+		Limit:     maxPositionsLimit,
+		MaxOffset: maxPositionsOffset,
+	})
+}
+
+// fetchAllPositions returns every position from params.Offset. It fails
+// rather than return a partial list when the offset cap is reached.
+func fetchAllPositions(ctx context.Context, userAddr string, params positionsParams) ([]Position, error) {
+	paginator := positionsPaginator(userAddr, params)
+
+	positions, err := paginator.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if paginator.Truncated() {
+		return nil, fmt.Errorf("more than %d positions match; narrow the query", maxPositionsOffset+maxPositionsLimit)
+	}
+
+	return positions, nil
+}
+
+// streamAllPositions prints every position, writing each page as soon as
+// it is fetched where the output format allows.
+func streamAllPositions(ctx context.Context, userAddr string, params positionsParams) {
+	paginator := positionsPaginator(userAddr, params)
+
+	count, err := streamPages(ctx, paginator, positionsOutput)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	if paginator.Truncated() {
+		fmt.Fprintf(os.Stderr, "Warning: stopped at the API offset cap of %d after %d positions; more may exist. Narrow the query with --market, --event-id or --size-threshold.\n", maxPositionsOffset, count)
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/internal/output"
+)
+
+type ProfileEntry struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage config profiles",
+	Long: `Profiles are named sets of settings under "profiles.<name>" in the config file.
+Keys not set in a profile fall back to the top level, and environment variables
+(POLYMARKET_BUILDER_API_KEY, POLYMARKET_PRIVATE_KEY, ...) override both.
+The active profile is chosen with --profile, POLYMARKET_PROFILE or "profile use".`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles defined in the config file",
+	Run: func(cmd *cobra.Command, args []string) {
+		entries := []ProfileEntry{}
+		for _, name := range config.Profiles() {
+			entries = append(entries, ProfileEntry{
+				Name:   name,
+				Active: name == config.AppCfg.Profile,
+			})
+		}
+
+		if err := render(entries, output.Options{}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the resolved settings of a profile (default: the active one)",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.AppCfg
+		if len(args) == 1 {
+			resolved, err := config.Resolve(args[0])
+			if err != nil {
+				printError(err)
+				return
+			}
+			cfg = resolved
+		}
+
+		if err := render(redactConfig(*cfg), output.Options{}); err != nil {
+			printError(err)
+		}
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the default by saving it to the config file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if _, err := config.Resolve(name); err != nil {
+			printError(err)
+			return
+		}
+
+		path := viper.ConfigFileUsed()
+		if path == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				printError(err)
+				return
+			}
+			path = filepath.Join(home, ".polymarket-cli.yaml")
+		}
+
+		if err := config.SetDefaultProfile(path, name); err != nil {
+			printError(fmt.Errorf("failed to write config: %w", err))
+			return
+		}
+
+		infof("Default profile set to %s in %s\n", name, path)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileShowCmd)
+	profileCmd.AddCommand(profileUseCmd)
+}
+
+// redactConfig hides secrets so a profile can be shown safely.
+func redactConfig(cfg config.Config) config.Config {
+	redact := func(value *string) {
+		if *value != "" {
+			*value = "***"
+		}
+	}
+
+	redact(&cfg.Builder.APISecret)
+	redact(&cfg.Builder.Passphrase)
+	redact(&cfg.PrivateKey)
+
+	return cfg
+}
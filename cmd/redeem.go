@@ -1,7 +1,8 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -9,85 +10,235 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/spf13/cobra"
 
+	"polymarket-cli/internal/chain"
 	"polymarket-cli/internal/config"
 	"polymarket-cli/pkg/relayer"
 	"polymarket-cli/pkg/relayer/transactions"
 )
 
 var (
-	txType string
+	txType  string
+	negRisk bool
 )
 
 var redeemCmd = &cobra.Command{
 	Use:   "redeem [condition-id]",
 	Short: "Redeem positions for a condition",
-	Long:  `Redeem positions for a given condition ID.`,
+	Long: `Redeem positions for a given condition ID.
+
+Neg-risk markets are redeemed through the NegRiskAdapter using the wallet's
+outcome token balances, read on chain when rpc_url is configured and
+otherwise taken from the data API rounded down to whole base units.
+Neg-risk conditions are detected from the positions data; use --neg-risk
+to force the adapter route.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
-			fmt.Println("Error: condition ID is required")
+			printError(usageError{errors.New("condition ID is required")})
 			return
 		}
 
 		conditionID, err := hexutil.Decode(args[0])
 		if err != nil {
-			fmt.Printf("Error: invalid condition ID: %v\n", err)
+			printError(usageError{fmt.Errorf("invalid condition ID: %w", err)})
+			return
+		}
+
+		if !hasSigner() {
+			printError(errNoSigner)
 			return
 		}
 
-		if len(config.AppCfg.PrivateKey) == 0 {
-			fmt.Println("Error: private key is required in config")
+		if submits() && len(config.AppCfg.Builder.APIKey) == 0 {
+			printError(errNoBuilderKey)
 			return
 		}
 
-		if len(config.AppCfg.Builder.APIKey) == 0 {
-			fmt.Println("Error: builder API key not configured")
+		client, err := newRelayerClient(selectedTxType())
+		if err != nil {
+			printError(err)
 			return
 		}
+		defer client.Close()
 
-		result, err := executeRedeem(conditionID)
+		txs, err := redeemTransactions(cmd.Context(), client, common.BytesToHash(conditionID))
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
+			printError(err)
 			return
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		result, err := submitTransactions(cmd.Context(), client, txs, "Redeem positions")
 		if err != nil {
-			fmt.Printf("Error formatting output: %v\n", err)
+			printError(err)
 			return
 		}
 
-		fmt.Printf("Redeem positions result: %s\n", string(jsonData))
+		if result != nil && wait {
+			waitForTransaction(cmd.Context(), client, result.TransactionID)
+		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(redeemCmd)
 
-	redeemCmd.Flags().StringVar(&txType, "tx-type", "SAFE", "Transaction type (SAFE or PROXY)")
+	redeemCmd.Flags().StringVar(&txType, "tx-type", "", "Transaction type (SAFE or PROXY; default: tx_type from config, else SAFE)")
+	redeemCmd.Flags().BoolVar(&negRisk, "neg-risk", false, "Redeem through the NegRiskAdapter (neg-risk market)")
+	addSigningFlags(redeemCmd)
+}
+
+// redeemTransactions builds the redeem call for a condition. When signing
+// offline without --neg-risk the data API is not consulted and the condition
+// is redeemed through the Conditional Tokens contract.
+func redeemTransactions(ctx context.Context, client *relayer.Client, conditionID common.Hash) ([]*transactions.Transaction, error) {
+	if offline() && !negRisk {
+		tx, err := buildRedeemTransaction(conditionID)
+		if err != nil {
+			return nil, err
+		}
+		return []*transactions.Transaction{tx}, nil
+	}
+
+	positions, err := fetchConditionPositions(ctx, client.WalletAddress().Hex(), conditionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+
+	chainClient, err := balanceClient()
+	if err != nil {
+		return nil, err
+	}
+	if chainClient != nil {
+		defer chainClient.Close()
+	}
+
+	tx, err := buildConditionRedeemTransaction(ctx, chainClient, client.WalletAddress(), conditionID, positions, negRisk)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*transactions.Transaction{tx}, nil
+}
+
+// fetchConditionPositions returns the wallet's positions in a single
+// condition, regardless of size.
+func fetchConditionPositions(ctx context.Context, wallet string, conditionID common.Hash) ([]Position, error) {
+	positions, err := fetchAllPositions(ctx, wallet, positionsParams{
+		Market: []string{conditionID.Hex()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return filterConditionPositions(positions, conditionID), nil
+}
+
+func filterConditionPositions(positions []Position, conditionID common.Hash) []Position {
+	var filtered []Position
+	for _, p := range positions {
+		if common.HexToHash(p.ConditionID) == conditionID {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
 }
 
-func executeRedeem(conditionID []byte) (*relayer.ExecuteResponse, error) {
-	creds := &relayer.BuilderCreds{
-		Key:        config.AppCfg.Builder.APIKey,
-		Secret:     config.AppCfg.Builder.APISecret,
-		Passphrase: config.AppCfg.Builder.Passphrase,
+// buildConditionRedeemTransaction builds the redeem call for a condition,
+// routing it through the NegRiskAdapter when forced or when any of the
+// wallet's positions in it is flagged as negative risk. chainClient reads
+// the amounts to redeem and may be nil; see outcomeBalances.
+func buildConditionRedeemTransaction(ctx context.Context, chainClient *chain.Client, wallet common.Address, conditionID common.Hash, positions []Position, forceNegRisk bool) (*transactions.Transaction, error) {
+	if !forceNegRisk && !isNegRisk(positions) {
+		return buildRedeemTransaction(conditionID)
 	}
 
-	relayerTxType := relayer.RelayerTxTypeSAFE
-	if txType == "PROXY" {
-		relayerTxType = relayer.RelayerTxTypePROXY
+	amounts, err := outcomeBalances(ctx, chainClient, wallet, positions)
+	if err != nil {
+		return nil, err
+	}
+	if amounts[0].Sign() == 0 && amounts[1].Sign() == 0 {
+		return nil, fmt.Errorf("no outcome token balance found for condition %s", conditionID.Hex())
 	}
 
-	client, err := relayer.NewClient(creds, relayerTxType, nil, &config.AppCfg.PrivateKey)
+	tx, err := transactions.BuildNegRiskRedeemTransaction(transactions.NegRiskRedeemParams{
+		NegRiskAdapter: activeNetwork.NegRiskAdapter,
+		ConditionID:    conditionID,
+		Amounts:        amounts,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create relayer client: %w", err)
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+func isNegRisk(positions []Position) bool {
+	for _, p := range positions {
+		if p.NegativeRisk {
+			return true
+		}
+	}
+	return false
+}
+
+// balanceClient connects to rpc_url for reading outcome token balances. It
+// returns nil when no RPC endpoint is configured or when signing offline,
+// in which case balances come from the data API.
+func balanceClient() (*chain.Client, error) {
+	if config.AppCfg.RPCURL == "" || offline() {
+		return nil, nil
+	}
+
+	return chain.NewClient(config.AppCfg.RPCURL)
+}
+
+// outcomeBalances returns wallet's balance per binary outcome index of the
+// tokens in positions, in base units. With a chainClient the balances are
+// read on chain; without one they are the data API sizes rounded down, so an
+// amount never exceeds what the wallet holds.
+func outcomeBalances(ctx context.Context, chainClient *chain.Client, wallet common.Address, positions []Position) ([]*big.Int, error) {
+	if chainClient == nil {
+		return outcomeAmounts(positions), nil
 	}
 
+	balances := []*big.Int{big.NewInt(0), big.NewInt(0)}
+	for _, p := range positions {
+		if p.OutcomeIndex < 0 || p.OutcomeIndex >= len(balances) {
+			continue
+		}
+
+		tokenID, ok := new(big.Int).SetString(p.Asset, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid token ID %q for condition %s", p.Asset, p.ConditionID)
+		}
+
+		balance, err := chainClient.TokenBalance(ctx, activeNetwork.CTF, wallet, tokenID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outcome token balance: %w", err)
+		}
+		balances[p.OutcomeIndex].Add(balances[p.OutcomeIndex], balance)
+	}
+
+	return balances, nil
+}
+
+// outcomeAmounts sums position sizes per binary outcome index, in base units.
+func outcomeAmounts(positions []Position) []*big.Int {
+	amounts := []*big.Int{big.NewInt(0), big.NewInt(0)}
+	for _, p := range positions {
+		if p.OutcomeIndex < 0 || p.OutcomeIndex >= len(amounts) {
+			continue
+		}
+		amounts[p.OutcomeIndex].Add(amounts[p.OutcomeIndex], toBaseUnits(p.Size))
+	}
+	return amounts
+}
+
+func buildRedeemTransaction(conditionID common.Hash) (*transactions.Transaction, error) {
 	params := transactions.RedeemParams{
-		ConditionalTokens:  relayer.CTF_ADDRESS,
-		CollateralToken:    relayer.USDC_ADDRESS,
+		ConditionalTokens:  activeNetwork.CTF,
+		CollateralToken:    activeNetwork.USDC,
 		ParentCollectionID: common.Hash{},
-		ConditionID:        common.BytesToHash(conditionID),
+		ConditionID:        conditionID,
 		IndexSets: []*big.Int{
 			big.NewInt(1),
 			big.NewInt(2),
@@ -99,5 +250,5 @@ func executeRedeem(conditionID []byte) (*relayer.ExecuteResponse, error) {
 		return nil, fmt.Errorf("failed to build transaction: %w", err)
 	}
 
-	return client.Execute([]*transactions.Transaction{tx}, "Redeem positions")
+	return tx, nil
 }
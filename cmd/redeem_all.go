@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+var batchSize int
+
+var redeemAllCmd = &cobra.Command{
+	Use:   "redeem-all",
+	Short: "Redeem every redeemable position",
+	Long: `Redeem every redeemable position held by the signer's wallet.
+
+Positions are looked up through the data API and one redeem call is built per
+distinct condition; neg-risk conditions are redeemed through the
+NegRiskAdapter. The calls are submitted as a single multisend, split into
+batches of --batch-size conditions. When more than one batch is needed each
+batch is waited on before the next one is submitted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !hasSigner() {
+			printError(errNoSigner)
+			return
+		}
+
+		if submits() && len(config.AppCfg.Builder.APIKey) == 0 {
+			printError(errNoBuilderKey)
+			return
+		}
+
+		if batchSize <= 0 {
+			printError(usageError{errors.New("batch size must be positive")})
+			return
+		}
+
+		client, err := newRelayerClient(selectedTxType())
+		if err != nil {
+			printError(err)
+			return
+		}
+		defer client.Close()
+
+		wallet := client.WalletAddress().Hex()
+		positions, err := fetchAllPositions(cmd.Context(), wallet, positionsParams{
+			Redeemable:    true,
+			SortBy:        "TOKENS",
+			SortDirection: "DESC",
+		})
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		conditionIDs, groups := groupRedeemablePositions(positions)
+		if len(conditionIDs) == 0 {
+			infof("No redeemable positions for %s\n", wallet)
+			return
+		}
+
+		chainClient, err := balanceClient()
+		if err != nil {
+			printError(err)
+			return
+		}
+		if chainClient != nil {
+			defer chainClient.Close()
+		}
+
+		txs := make([]*transactions.Transaction, 0, len(conditionIDs))
+		for _, conditionID := range conditionIDs {
+			tx, err := buildConditionRedeemTransaction(cmd.Context(), chainClient, client.WalletAddress(), conditionID, groups[conditionID], false)
+			if err != nil {
+				printError(err)
+				return
+			}
+			txs = append(txs, tx)
+		}
+
+		batches := chunkTransactions(txs, batchSize)
+		if len(batches) > 1 && (outFile != "" || offline()) {
+			printError(usageError{fmt.Errorf("%d batches needed; --out and --nonce sign a single request, raise --batch-size", len(batches))})
+			return
+		}
+
+		for i, batch := range batches {
+			infof("Submitting batch %d/%d (%d conditions)\n", i+1, len(batches), len(batch))
+
+			result, err := submitTransactions(cmd.Context(), client, batch, fmt.Sprintf("Redeem %d positions", len(batch)))
+			if err != nil {
+				printError(err)
+				return
+			}
+
+			if result == nil {
+				continue
+			}
+
+			if wait || i < len(batches)-1 {
+				if !waitForTransaction(cmd.Context(), client, result.TransactionID) {
+					return
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(redeemAllCmd)
+
+	redeemAllCmd.Flags().StringVar(&txType, "tx-type", "", "Transaction type (SAFE or PROXY; default: tx_type from config, else SAFE)")
+	redeemAllCmd.Flags().IntVar(&batchSize, "batch-size", 20, "Maximum number of conditions per relayer submission")
+	addSigningFlags(redeemAllCmd)
+}
+
+// groupRedeemablePositions groups redeemable positions by condition ID,
+// keeping conditions in the order they first appear.
+func groupRedeemablePositions(positions []Position) ([]common.Hash, map[common.Hash][]Position) {
+	groups := make(map[common.Hash][]Position)
+
+	var conditionIDs []common.Hash
+	for _, p := range positions {
+		if !p.Redeemable {
+			continue
+		}
+
+		conditionID := common.HexToHash(p.ConditionID)
+		if _, ok := groups[conditionID]; !ok {
+			conditionIDs = append(conditionIDs, conditionID)
+		}
+		groups[conditionID] = append(groups[conditionID], p)
+	}
+
+	return conditionIDs, groups
+}
+
+func chunkTransactions(txs []*transactions.Transaction, size int) [][]*transactions.Transaction {
+	var chunks [][]*transactions.Transaction
+	for size < len(txs) {
+		chunks = append(chunks, txs[:size])
+		txs = txs[size:]
+	}
+	return append(chunks, txs)
+}
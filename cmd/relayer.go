@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/relayer"
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+var (
+	wait         bool
+	waitTimeout  time.Duration
+	dryRun       bool
+	offlineNonce string
+	relayAddress string
+	outFile      string
+)
+
+var relayerCmd = &cobra.Command{
+	Use:   "relayer",
+	Short: "Interact with the Polymarket relayer directly",
+}
+
+var relayerSubmitCmd = &cobra.Command{
+	Use:   "submit [file]",
+	Short: "Submit a signed relayer request",
+	Long: `Submit a relayer request previously signed with --out, for example on an
+air-gapped machine with --nonce. Only the builder API credentials are needed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printError(usageError{errors.New("request file is required")})
+			return
+		}
+
+		if len(config.AppCfg.Builder.APIKey) == 0 {
+			printError(errNoBuilderKey)
+			return
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		var request transactions.TransactionRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			printError(fmt.Errorf("invalid request file: %w", err))
+			return
+		}
+
+		client, err := newRelayerClientWithSigner(relayer.RelayerTxType(request.Type), nil)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		result, err := client.SubmitContext(cmd.Context(), &request)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if err := printResult("Submit result", result); err != nil {
+			printError(err)
+			return
+		}
+
+		if wait {
+			waitForTransaction(cmd.Context(), client, result.TransactionID)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(relayerCmd)
+	relayerCmd.AddCommand(relayerSubmitCmd)
+
+	addWaitFlags(relayerSubmitCmd)
+}
+
+// newRelayerClient creates a relayer client signing with the configured
+// signer.
+func newRelayerClient(relayerTxType relayer.RelayerTxType) (*relayer.Client, error) {
+	signer, err := loadSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newRelayerClientWithSigner(relayerTxType, signer)
+	if err != nil {
+		closeSigner(signer)
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func newRelayerClientWithSigner(relayerTxType relayer.RelayerTxType, signer relayer.Signer) (*relayer.Client, error) {
+	creds := &relayer.BuilderCreds{
+		Key:        config.AppCfg.Builder.APIKey,
+		Secret:     config.AppCfg.Builder.APISecret,
+		Passphrase: config.AppCfg.Builder.Passphrase,
+	}
+
+	client, err := relayer.NewClient(
+		relayer.WithNetwork(activeNetwork),
+		relayer.WithCreds(creds),
+		relayer.WithTxType(relayerTxType),
+		relayer.WithSigner(signer),
+		relayer.WithLogger(logger),
+		relayer.WithHTTPClient(apiHTTPClient()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relayer client: %w", err)
+	}
+
+	if offlineNonce != "" {
+		nonce, ok := new(big.Int).SetString(offlineNonce, 10)
+		if !ok {
+			return nil, usageError{fmt.Errorf("invalid nonce: %s", offlineNonce)}
+		}
+		client.SetNonce(nonce)
+	}
+
+	if relayAddress != "" {
+		if !common.IsHexAddress(relayAddress) {
+			return nil, usageError{fmt.Errorf("invalid relay address: %s", relayAddress)}
+		}
+		client.SetRelay(common.HexToAddress(relayAddress))
+	}
+
+	return client, nil
+}
+
+// selectedTxType returns the relayer transaction type chosen with --tx-type,
+// falling back to tx_type from config.
+func selectedTxType() relayer.RelayerTxType {
+	selected := strings.ToUpper(txType)
+	if selected == "" {
+		selected = config.AppCfg.TxType
+	}
+
+	if selected == "PROXY" {
+		return relayer.RelayerTxTypePROXY
+	}
+	return relayer.RelayerTxTypeSAFE
+}
+
+func addWaitFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&wait, "wait", false, "Wait for the transaction to be mined and print its final state")
+	c.Flags().DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum time to wait with --wait")
+}
+
+// addSigningFlags registers the flags shared by every command that signs a
+// relayer request.
+func addSigningFlags(c *cobra.Command) {
+	addWaitFlags(c)
+	c.Flags().BoolVar(&dryRun, "dry-run", false, "Build and sign the request and print it without submitting")
+	c.Flags().StringVar(&offlineNonce, "nonce", "", "Sign with this relayer nonce instead of fetching it (offline signing)")
+	c.Flags().StringVar(&relayAddress, "relay-address", "", "Relay address to sign into PROXY requests when using --nonce")
+	c.Flags().StringVar(&outFile, "out", "", "Write the signed request to this file instead of submitting it (see 'relayer submit')")
+}
+
+// offline reports whether the request is signed without talking to the
+// relayer, in which case lookups that need the network should be avoided.
+func offline() bool {
+	return offlineNonce != ""
+}
+
+// submits reports whether the command sends its request to the relayer.
+// With --dry-run or --out it only signs, so builder credentials are not
+// needed.
+func submits() bool {
+	return !dryRun && outFile == ""
+}
+
+// submitTransactions executes txs through the relayer and prints the result.
+// With --dry-run or --out the signed request is printed or saved instead and
+// a nil response is returned.
+func submitTransactions(ctx context.Context, client *relayer.Client, txs []*transactions.Transaction, metadata string) (*relayer.ExecuteResponse, error) {
+	if !submits() {
+		prepared, err := client.BuildContext(ctx, txs, metadata)
+		if err != nil {
+			return nil, err
+		}
+		return nil, emitPrepared(client, prepared)
+	}
+
+	result, err := client.ExecuteContext(ctx, txs, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := printResult(metadata+" result", result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type DryRunCall struct {
+	To      string                    `json:"to"`
+	Value   string                    `json:"value"`
+	Data    string                    `json:"data"`
+	Decoded *transactions.DecodedCall `json:"decoded,omitempty"`
+}
+
+type DryRunResult struct {
+	Type       string                           `json:"type"`
+	Signer     string                           `json:"signer"`
+	Wallet     string                           `json:"wallet"`
+	Multisend  bool                             `json:"multisend"`
+	StructHash string                           `json:"structHash"`
+	Signature  string                           `json:"signature"`
+	Calls      []DryRunCall                     `json:"calls"`
+	Request    *transactions.TransactionRequest `json:"request"`
+}
+
+func emitPrepared(client *relayer.Client, prepared *relayer.PreparedTransaction) error {
+	request := prepared.Request
+
+	if dryRun {
+		result := DryRunResult{
+			Type:       request.Type,
+			Signer:     request.From,
+			Wallet:     client.WalletAddress().Hex(),
+			Multisend:  request.Type == string(relayer.RelayerTxTypeSAFE) && len(prepared.Transactions) > 1,
+			StructHash: "0x" + hex.EncodeToString(prepared.StructHash),
+			Signature:  request.Signature,
+			Calls:      make([]DryRunCall, 0, len(prepared.Transactions)),
+			Request:    request,
+		}
+		if request.ProxyWallet != nil {
+			result.Wallet = *request.ProxyWallet
+		}
+
+		for _, tx := range prepared.Transactions {
+			call := DryRunCall{
+				To:    tx.To.Hex(),
+				Value: tx.Value.String(),
+				Data:  "0x" + hex.EncodeToString(tx.Data),
+			}
+			if decoded, err := transactions.DecodeCalldata(tx.Data); err == nil {
+				call.Decoded = decoded
+			}
+			result.Calls = append(result.Calls, call)
+		}
+
+		if err := printResult("Dry run (not submitted)", result); err != nil {
+			return err
+		}
+	}
+
+	if outFile != "" {
+		jsonData, err := json.MarshalIndent(request, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format request: %w", err)
+		}
+
+		if err := os.WriteFile(outFile, append(jsonData, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write request: %w", err)
+		}
+
+		infof("Signed request written to %s\n", outFile)
+	}
+
+	return nil
+}
+
+// waitForTransaction polls the relayer until the transaction settles, prints
+// its final state and reports whether it made it on chain.
+func waitForTransaction(ctx context.Context, client *relayer.Client, transactionID string) bool {
+	infof("Waiting for transaction %s...\n", transactionID)
+
+	tx, err := client.WaitForStateContext(ctx, transactionID, waitTimeout)
+	if tx != nil {
+		infof("State: %s\n", tx.State)
+		if tx.TransactionHash != "" {
+			infof("Transaction hash: %s\n", tx.TransactionHash)
+		}
+	}
+	if err != nil {
+		printError(err)
+		return false
+	}
+	return true
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strings"
 
 	"polymarket-cli/internal/config"
 
@@ -10,7 +13,12 @@ import (
 	"github.com/spf13/viper"
 )
 
-var cfgFile string
+var (
+	cfgFile string
+	profile string
+	verbose bool
+	logger  = slog.New(slog.DiscardHandler)
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "polymarket-cli",
@@ -18,14 +26,29 @@ var rootCmd = &cobra.Command{
 	Long:  `A powerful CLI application for interacting with Polymarket data and APIs.`,
 }
 
+// Execute runs the root command with a context that is cancelled on
+// Ctrl-C, so in-flight relayer requests and waits are abandoned.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		return usageError{err}
+	}
+
+	return commandErr
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.polymarket-cli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "config profile to use (env "+config.EnvName("profile")+")")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "output format: json, ndjson, csv, table, template=<go template> or jsonpath=<expr> (default from output_format in config)")
+	rootCmd.PersistentFlags().StringSliceVar(&outputColumns, "columns", nil, "comma-separated columns to output (nested fields as a.b)")
+	rootCmd.PersistentFlags().StringVar(&outputSort, "sort", "", "sort output by a column, descending with a leading '-'")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "log relayer requests to stderr")
+	rootCmd.PersistentFlags().IntVar(&passphraseFD, "passphrase-fd", -1, "read the keystore passphrase from this file descriptor")
 }
 
 func initConfig() {
@@ -40,11 +63,25 @@ func initConfig() {
 		viper.SetConfigName(".polymarket-cli")
 	}
 
+	viper.SetEnvPrefix(config.EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
+	cobra.CheckErr(viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile")))
+
+	readErr := viper.ReadInConfig()
+
+	if verbose {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	cobra.CheckErr(config.Init())
 
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	// Announced once the config is loaded, so that infof sees output_format.
+	if readErr == nil {
+		infof("Using config file: %s\n", viper.ConfigFileUsed())
 	}
 
-	config.Init()
+	network, err := resolveNetwork(config.AppCfg)
+	cobra.CheckErr(err)
+	activeNetwork = network
 }
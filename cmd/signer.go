@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/term"
+
+	"polymarket-cli/internal/config"
+	"polymarket-cli/internal/keys"
+	"polymarket-cli/pkg/relayer"
+)
+
+const passphraseEnv = "POLYMARKET_KEYSTORE_PASSPHRASE"
+
+var passphraseFD int
+
+var errNoSigner = errors.New("no signer configured: set key, signer.keystore, signer.external or private_key in config")
+
+// loadSigner builds the signer selected in config, preferring an external
+// signer, then an encrypted keystore (by path or by key name/address), then
+// a raw private key.
+func loadSigner() (relayer.Signer, error) {
+	cfg := config.AppCfg
+
+	if cfg.Signer.External != "" {
+		var address common.Address
+		if cfg.Signer.Address != "" {
+			if !common.IsHexAddress(cfg.Signer.Address) {
+				return nil, fmt.Errorf("invalid signer address: %s", cfg.Signer.Address)
+			}
+			address = common.HexToAddress(cfg.Signer.Address)
+		}
+		return relayer.NewExternalSigner(cfg.Signer.External, address)
+	}
+
+	path, err := keystorePath()
+	if err != nil {
+		return nil, err
+	}
+
+	if path != "" {
+		keyJSON, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore: %w", err)
+		}
+
+		passphrase, err := readPassphrase(fmt.Sprintf("Passphrase for %s: ", path))
+		if err != nil {
+			return nil, err
+		}
+
+		return relayer.NewKeystoreSigner(keyJSON, passphrase)
+	}
+
+	if cfg.PrivateKey != "" {
+		return relayer.NewHexKeySigner(cfg.PrivateKey)
+	}
+
+	return nil, errNoSigner
+}
+
+// keystorePath returns the keystore file selected by signer.keystore or by
+// key, or "" when neither is configured.
+func keystorePath() (string, error) {
+	cfg := config.AppCfg
+
+	if cfg.Signer.Keystore != "" {
+		return cfg.Signer.Keystore, nil
+	}
+
+	if cfg.Key != "" {
+		key, err := keys.NewStore(cfg.KeystoreDir).Find(cfg.Key)
+		if err != nil {
+			return "", err
+		}
+		return key.Path, nil
+	}
+
+	return "", nil
+}
+
+// hasSigner reports whether any signer is configured.
+func hasSigner() bool {
+	cfg := config.AppCfg
+	return cfg.Signer.External != "" || cfg.Signer.Keystore != "" || cfg.Key != "" || cfg.PrivateKey != ""
+}
+
+// signerAddress returns the owner address of the configured signer without
+// unlocking it where possible: keystore files carry their address in clear.
+func signerAddress() (common.Address, error) {
+	cfg := config.AppCfg
+
+	if cfg.Signer.External != "" {
+		if cfg.Signer.Address != "" {
+			return common.HexToAddress(cfg.Signer.Address), nil
+		}
+	} else {
+		path, err := keystorePath()
+		if err != nil {
+			return common.Address{}, err
+		}
+		if path != "" {
+			return keys.ReadAddress(path)
+		}
+	}
+
+	signer, err := loadSigner()
+	if err != nil {
+		return common.Address{}, err
+	}
+	defer closeSigner(signer)
+
+	return signer.Address(), nil
+}
+
+// closeSigner releases signers that hold a connection, such as an external
+// signer.
+func closeSigner(signer relayer.Signer) {
+	if closer, ok := signer.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// readPassphrase returns the keystore passphrase from --passphrase-fd, the
+// environment or a terminal prompt, in that order.
+func readPassphrase(prompt string) (string, error) {
+	if passphraseFD >= 0 {
+		return readPassphraseFD(passphraseFD)
+	}
+
+	if passphrase, ok := os.LookupEnv(passphraseEnv); ok {
+		return passphrase, nil
+	}
+
+	return promptSecret(prompt)
+}
+
+// readNewPassphrase is readPassphrase for a passphrase being chosen: when
+// prompting on a terminal it is asked for twice.
+func readNewPassphrase() (string, error) {
+	if _, ok := os.LookupEnv(passphraseEnv); passphraseFD >= 0 || ok {
+		return readPassphrase("")
+	}
+
+	passphrase, err := promptSecret("New passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	confirm, err := promptSecret("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase != confirm {
+		return "", errors.New("passphrases do not match")
+	}
+
+	return passphrase, nil
+}
+
+func readPassphraseFD(fd int) (string, error) {
+	// os.NewFile only returns nil for negative descriptors, so check that fd
+	// is actually open before reading from it.
+	file := os.NewFile(uintptr(fd), "passphrase")
+	if _, err := file.Stat(); err != nil {
+		return "", usageError{fmt.Errorf("invalid --passphrase-fd %d: %w", fd, err)}
+	}
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read passphrase from fd %d: %w", fd, err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func promptSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("passphrase required: use --passphrase-fd, set %s or run from a terminal", passphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return string(secret), nil
+}
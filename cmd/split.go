@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/chain"
+	"polymarket-cli/internal/config"
+	"polymarket-cli/pkg/relayer"
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+var splitApprove bool
+
+var splitCmd = &cobra.Command{
+	Use:   "split [condition-id]",
+	Short: "Split USDC into YES and NO outcome tokens",
+	Long: `Split --amount USDC into the same number of full YES/NO sets for a condition.
+
+A USDC approve of --amount for the Conditional Tokens contract (or the
+NegRiskAdapter with --neg-risk) is prepended when the wallet's allowance,
+read over rpc_url, is below --amount. Both calls are submitted as a single
+multisend.
+
+Without rpc_url, or when signing offline, the allowance cannot be read and
+the split is refused unless --approve is given: --approve prepends an
+unlimited approve, as 'approvals set' grants, so an existing allowance is
+never lowered, and --approve=false leaves the approve out.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printError(usageError{errors.New("condition ID is required")})
+			return
+		}
+
+		conditionID, err := hexutil.Decode(args[0])
+		if err != nil {
+			printError(usageError{fmt.Errorf("invalid condition ID: %w", err)})
+			return
+		}
+
+		if amount == "" {
+			printError(usageError{errors.New("--amount is required")})
+			return
+		}
+
+		splitAmount, err := parseBaseUnits(amount)
+		if err != nil {
+			printError(usageError{err})
+			return
+		}
+
+		if !hasSigner() {
+			printError(errNoSigner)
+			return
+		}
+
+		if submits() && len(config.AppCfg.Builder.APIKey) == 0 {
+			printError(errNoBuilderKey)
+			return
+		}
+
+		client, err := newRelayerClient(selectedTxType())
+		if err != nil {
+			printError(err)
+			return
+		}
+		defer client.Close()
+
+		var approve *bool
+		if cmd.Flags().Changed("approve") {
+			approve = &splitApprove
+		}
+
+		txs, err := splitTransactions(cmd.Context(), client, common.BytesToHash(conditionID), splitAmount, approve)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		result, err := submitTransactions(cmd.Context(), client, txs, "Split position")
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if result != nil && wait {
+			waitForTransaction(cmd.Context(), client, result.TransactionID)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(splitCmd)
+
+	splitCmd.Flags().StringVar(&txType, "tx-type", "", "Transaction type (SAFE or PROXY; default: tx_type from config, else SAFE)")
+	splitCmd.Flags().StringVar(&amount, "amount", "", "Amount of USDC to split into full sets")
+	splitCmd.Flags().BoolVar(&negRisk, "neg-risk", false, "Split through the NegRiskAdapter (neg-risk market)")
+	splitCmd.Flags().BoolVar(&splitApprove, "approve", false, "Prepend an unlimited USDC approve when the allowance cannot be read (--approve=false to omit it)")
+	addSigningFlags(splitCmd)
+}
+
+func splitTransactions(ctx context.Context, client *relayer.Client, conditionID common.Hash, splitAmount *big.Int, approve *bool) ([]*transactions.Transaction, error) {
+	spender := activeNetwork.CTF
+	if negRisk {
+		spender = activeNetwork.NegRiskAdapter
+	}
+
+	var txs []*transactions.Transaction
+
+	approval, err := usdcApprovalAmount(ctx, client.WalletAddress(), spender, splitAmount, approve)
+	if err != nil {
+		return nil, err
+	}
+	if approval != nil {
+		tx, err := transactions.BuildApproveTransaction(transactions.ApproveParams{
+			Token:   activeNetwork.USDC,
+			Spender: spender,
+			Amount:  approval,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build transaction: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	tx, err := buildSplitTransaction(conditionID, splitAmount, negRisk)
+	if err != nil {
+		return nil, err
+	}
+	txs = append(txs, tx)
+
+	infof("Splitting %s USDC for condition %s\n", formatBaseUnits(splitAmount), conditionID.Hex())
+
+	return txs, nil
+}
+
+// usdcApprovalAmount returns the USDC allowance to approve for spender
+// before pulling amount from wallet, or nil when none is needed. An approve
+// only ever raises the allowance: to amount when the allowance read over
+// rpc_url is below it, or to unlimited with --approve when the allowance
+// cannot be read. approve is the --approve flag, nil when not given; an
+// unreadable allowance without it is an error.
+func usdcApprovalAmount(ctx context.Context, wallet, spender common.Address, amount *big.Int, approve *bool) (*big.Int, error) {
+	if config.AppCfg.RPCURL == "" || offline() {
+		switch {
+		case approve == nil:
+			return nil, usageError{errors.New("cannot read the USDC allowance without rpc_url or when signing offline; pass --approve to include an unlimited approve, or --approve=false if the allowance already covers --amount")}
+		case *approve:
+			return math.MaxBig256, nil
+		default:
+			return nil, nil
+		}
+	}
+
+	chainClient, err := chain.NewClient(config.AppCfg.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	defer chainClient.Close()
+
+	allowance, err := chainClient.Allowance(ctx, activeNetwork.USDC, wallet, spender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read USDC allowance: %w", err)
+	}
+
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+	return amount, nil
+}
+
+func buildSplitTransaction(conditionID common.Hash, splitAmount *big.Int, negRisk bool) (*transactions.Transaction, error) {
+	var (
+		tx  *transactions.Transaction
+		err error
+	)
+
+	if negRisk {
+		tx, err = transactions.BuildNegRiskSplitTransaction(transactions.NegRiskSplitParams{
+			NegRiskAdapter: activeNetwork.NegRiskAdapter,
+			ConditionID:    conditionID,
+			Amount:         splitAmount,
+		})
+	} else {
+		tx, err = transactions.BuildSplitTransaction(transactions.SplitParams{
+			ConditionalTokens:  activeNetwork.CTF,
+			CollateralToken:    activeNetwork.USDC,
+			ParentCollectionID: common.Hash{},
+			ConditionID:        conditionID,
+			Partition:          binaryPartition(),
+			Amount:             splitAmount,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	return tx, nil
+}
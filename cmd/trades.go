@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/client"
+	"polymarket-cli/internal/output"
+)
+
+var (
+	tradesMarket    []string
+	tradesEventID   []int
+	tradesSide      string
+	tradesTakerOnly bool
+	tradesMinCash   float64
+	tradesMinTokens float64
+	tradesStart     string
+	tradesEnd       string
+	tradesLimit     int
+	tradesOffset    int
+	allTrades       bool
+)
+
+var tradesCmd = &cobra.Command{
+	Use:   "trades [user-address]",
+	Short: "Get trade history for a user or market",
+	Long: `Returns fills, newest first, for a user and/or the markets given with
+--market or --event-id.
+
+When neither a user address nor a market filter is given, the Safe derived
+from the configured signer is used. By default only fills where the user
+was the taker are returned; pass --taker-only=false to include maker fills.
+
+The API has no time filter, so --start and --end are applied to the fetched
+fills. They take a Unix timestamp, a date (2006-01-02) or an RFC 3339 time.
+With --all, pages of up to 500 fills are fetched until the history is
+exhausted or, with --start, until fills older than --start are reached. The
+API does not accept offsets above 10000; results beyond it are cut off with
+a warning.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		params, err := tradesParamsFromFlags(cmd)
+		if err != nil {
+			printError(usageError{err})
+			return
+		}
+
+		if len(args) > 0 || (len(params.Market) == 0 && len(params.EventID) == 0) {
+			if params.User, err = userAddress(args); err != nil {
+				printError(err)
+				return
+			}
+		}
+
+		if allTrades {
+			streamAllTrades(cmd.Context(), params)
+			return
+		}
+
+		trades, err := fetchTrades(cmd.Context(), params)
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if err := render(filterTrades(trades, params.Start, params.End), tradesOutput); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tradesCmd)
+
+	tradesCmd.Flags().StringSliceVar(&tradesMarket, "market", []string{}, "Comma-separated list of condition IDs")
+	tradesCmd.Flags().IntSliceVar(&tradesEventID, "event-id", []int{}, "Comma-separated list of event IDs")
+	tradesCmd.Flags().StringVar(&tradesSide, "side", "", "Filter by side (BUY, SELL)")
+	tradesCmd.Flags().BoolVar(&tradesTakerOnly, "taker-only", true, "Only return fills where the user was the taker")
+	tradesCmd.Flags().Float64Var(&tradesMinCash, "min-cash", 0, "Minimum fill size in USDC")
+	tradesCmd.Flags().Float64Var(&tradesMinTokens, "min-tokens", 0, "Minimum fill size in outcome tokens")
+	tradesCmd.Flags().StringVar(&tradesStart, "start", "", "Only fills at or after this time")
+	tradesCmd.Flags().StringVar(&tradesEnd, "end", "", "Only fills at or before this time")
+	tradesCmd.Flags().IntVar(&tradesLimit, "limit", 100, "Limit results (0-500)")
+	tradesCmd.Flags().IntVar(&tradesOffset, "offset", 0, "Offset for pagination (0-10000)")
+	tradesCmd.Flags().BoolVar(&allTrades, "all", false, "Fetch every page of trades (ignores --limit)")
+	tradesCmd.MarkFlagsMutuallyExclusive("min-cash", "min-tokens")
+}
+
+type Trade struct {
+	ProxyWallet     string  `json:"proxyWallet"`
+	Side            string  `json:"side"`
+	Asset           string  `json:"asset"`
+	ConditionID     string  `json:"conditionId"`
+	Size            float64 `json:"size"`
+	Price           float64 `json:"price"`
+	Timestamp       int64   `json:"timestamp"`
+	Title           string  `json:"title"`
+	Slug            string  `json:"slug"`
+	Icon            string  `json:"icon"`
+	EventSlug       string  `json:"eventSlug"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcomeIndex"`
+	Name            string  `json:"name"`
+	Pseudonym       string  `json:"pseudonym"`
+	TransactionHash string  `json:"transactionHash"`
+}
+
+type tradesParams struct {
+	User      string
+	Market    []string
+	EventID   []int
+	Side      string
+	TakerOnly bool
+	// FilterType is CASH or TOKENS when FilterAmount is set.
+	FilterType   string
+	FilterAmount float64
+	Start        int64
+	End          int64
+	Limit        int
+	Offset       int
+}
+
+// tradesOutput sets the default table columns and number formats for
+// trades.
+var tradesOutput = output.Options{
+	TableColumns: []string{"timestamp", "side", "title", "outcome", "size", "price", "transactionHash"},
+	Number: map[string]string{
+		"size":  "%.2f",
+		"price": "%.3f",
+	},
+}
+
+const (
+	maxTradesLimit  = 500
+	maxTradesOffset = 10000
+)
+
+func tradesParamsFromFlags(cmd *cobra.Command) (tradesParams, error) {
+	params := tradesParams{
+		Market:    tradesMarket,
+		EventID:   tradesEventID,
+		Side:      strings.ToUpper(tradesSide),
+		TakerOnly: tradesTakerOnly,
+		Limit:     tradesLimit,
+		Offset:    tradesOffset,
+	}
+
+	if params.Side != "" && params.Side != "BUY" && params.Side != "SELL" {
+		return tradesParams{}, fmt.Errorf("invalid side %q (expected BUY or SELL)", tradesSide)
+	}
+
+	switch {
+	case cmd.Flags().Changed("min-cash"):
+		params.FilterType, params.FilterAmount = "CASH", tradesMinCash
+	case cmd.Flags().Changed("min-tokens"):
+		params.FilterType, params.FilterAmount = "TOKENS", tradesMinTokens
+	}
+	if params.FilterAmount < 0 {
+		return tradesParams{}, errors.New("minimum size must not be negative")
+	}
+
+	var err error
+	if params.Start, err = parseTimestamp(tradesStart); err != nil {
+		return tradesParams{}, fmt.Errorf("invalid --start: %w", err)
+	}
+	if params.End, err = parseTimestamp(tradesEnd); err != nil {
+		return tradesParams{}, fmt.Errorf("invalid --end: %w", err)
+	}
+	if params.Start > 0 && params.End > 0 && params.End < params.Start {
+		return tradesParams{}, errors.New("--end is before --start")
+	}
+
+	return params, nil
+}
+
+func fetchTrades(ctx context.Context, params tradesParams) ([]Trade, error) {
+	httpClient := newDataAPIClient()
+
+	query := url.Values{}
+	if params.User != "" {
+		query.Set("user", params.User)
+	}
+
+	for _, m := range params.Market {
+		query.Add("market", m)
+	}
+
+	for _, id := range params.EventID {
+		query.Add("eventId", fmt.Sprintf("%d", id))
+	}
+
+	if params.Side != "" {
+		query.Set("side", params.Side)
+	}
+
+	query.Set("takerOnly", fmt.Sprintf("%t", params.TakerOnly))
+
+	if params.FilterType != "" {
+		query.Set("filterType", params.FilterType)
+		query.Set("filterAmount", fmt.Sprintf("%g", params.FilterAmount))
+	}
+
+	query.Set("limit", fmt.Sprintf("%d", params.Limit))
+	query.Set("offset", fmt.Sprintf("%d", params.Offset))
+
+	var trades []Trade
+	if err := httpClient.GetJSONWithMultipleValuesContext(ctx, "/trades", query, &trades); err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
+
+// filterTrades keeps the trades between start and end, inclusive. Zero
+// bounds are open.
+func filterTrades(trades []Trade, start, end int64) []Trade {
+	if start == 0 && end == 0 {
+		return trades
+	}
+
+	filtered := make([]Trade, 0, len(trades))
+	for _, trade := range trades {
+		if (start > 0 && trade.Timestamp < start) || (end > 0 && trade.Timestamp > end) {
+			continue
+		}
+		filtered = append(filtered, trade)
+	}
+
+	return filtered
+}
+
+// tradesPaginator pages through /trades from params.Offset.
+func tradesPaginator(params tradesParams) *client.Paginator[Trade] {
+	fetch := func(ctx context.Context, offset, limit int) ([]Trade, error) {
+		params.Offset = offset
+		params.Limit = limit
+		return fetchTrades(ctx, params)
+	}
+
+	return client.NewPaginator(fetch, client.PageOptions{
+		Offset:    params.Offset,
+		Limit:     maxTradesLimit,
+		MaxOffset: maxTradesOffset,
+	})
+}
+
+// streamAllTrades prints every trade in the time range, writing each page
+// as soon as it is fetched where the output format allows. Trades come
+// newest first, so paging stops at the first one older than params.Start.
+func streamAllTrades(ctx context.Context, params tradesParams) {
+	stream, err := newStream(tradesOutput)
+	if err != nil {
+		printError(err)
+		return
+	}
+
+	paginator := tradesPaginator(params)
+
+	count := 0
+	reachedStart := false
+pages:
+	for paginator.Next(ctx) {
+		for _, trade := range paginator.Page() {
+			if params.Start > 0 && trade.Timestamp < params.Start {
+				reachedStart = true
+				break pages
+			}
+			if params.End > 0 && trade.Timestamp > params.End {
+				continue
+			}
+			if err := stream.Write(trade); err != nil {
+				printError(err)
+				return
+			}
+			count++
+		}
+	}
+
+	if err := stream.Close(); err != nil {
+		printError(err)
+		return
+	}
+
+	if err := paginator.Err(); err != nil {
+		printError(err)
+		return
+	}
+
+	if paginator.Truncated() && !reachedStart {
+		fmt.Fprintf(os.Stderr, "Warning: stopped at the API offset cap of %d after %d trades; more may exist. Narrow the query with --market, --side, --min-cash or --min-tokens.\n", maxTradesOffset, count)
+	}
+}
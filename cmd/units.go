@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// tokenDecimals is the number of decimals used by USDC and by the CTF
+// outcome tokens.
+const tokenDecimals = 6
+
+// toBaseUnits converts a decimal token amount, as reported by the data API,
+// to on-chain base units. The amount is taken as the shortest decimal that
+// represents it and digits beyond tokenDecimals are dropped, never rounded
+// up, so the result does not exceed the balance the API reported.
+func toBaseUnits(amount float64) *big.Int {
+	whole, frac, _ := strings.Cut(strconv.FormatFloat(amount, 'f', -1, 64), ".")
+	if len(frac) > tokenDecimals {
+		frac = frac[:tokenDecimals]
+	}
+	units, _ := new(big.Int).SetString(whole+frac+strings.Repeat("0", tokenDecimals-len(frac)), 10)
+	return units
+}
+
+// parseBaseUnits parses a decimal token amount such as "12.5" into on-chain
+// base units. Amounts with more than tokenDecimals fractional digits are
+// rejected rather than rounded.
+func parseBaseUnits(amount string) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if len(frac) > tokenDecimals {
+		return nil, fmt.Errorf("invalid amount %q: at most %d decimals allowed", amount, tokenDecimals)
+	}
+	if whole == "" && frac == "" {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	digits := whole + frac + strings.Repeat("0", tokenDecimals-len(frac))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+
+	units, _ := new(big.Int).SetString(digits, 10)
+	if units.Sign() == 0 {
+		return nil, fmt.Errorf("invalid amount %q: must be greater than zero", amount)
+	}
+
+	return units, nil
+}
+
+// nativeDecimals is the number of decimals of the native POL token.
+const nativeDecimals = 18
+
+// formatBaseUnits formats on-chain base units as a decimal token amount.
+func formatBaseUnits(units *big.Int) string {
+	return formatUnits(units, tokenDecimals)
+}
+
+// formatUnits formats an integer amount of a token with the given number of
+// decimals.
+func formatUnits(units *big.Int, decimals int) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(units, scale, new(big.Int))
+	return fmt.Sprintf("%s.%0*s", whole, decimals, frac)
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"polymarket-cli/internal/chain"
+	"polymarket-cli/internal/config"
+	"polymarket-cli/internal/output"
+	"polymarket-cli/pkg/relayer"
+)
+
+var walletOwner string
+
+type WalletAccount struct {
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	Deployed *bool  `json:"deployed,omitempty"`
+	USDC     string `json:"usdc,omitempty"`
+	POL      string `json:"pol,omitempty"`
+}
+
+// walletOutput sets the default table columns for wallet info. Deployment
+// status and balances are only present with rpc_url, and the EOA has no
+// deployment status.
+var walletOutput = output.Options{
+	TableColumns: []string{"type", "address", "deployed", "usdc", "pol"},
+}
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Manage the relayer wallet",
+	Long:  `Inspect and manage the Safe or proxy wallet the relayer acts on.`,
+}
+
+var walletDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy the signer's Safe through the relayer",
+	Long:  `Deploys the Safe derived from the configured signer. The deployment is gasless and submitted through the relayer.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !hasSigner() {
+			printError(errNoSigner)
+			return
+		}
+
+		if submits() && len(config.AppCfg.Builder.APIKey) == 0 {
+			printError(errNoBuilderKey)
+			return
+		}
+
+		client, err := newRelayerClient(relayer.RelayerTxTypeSAFE)
+		if err != nil {
+			printError(err)
+			return
+		}
+		defer client.Close()
+
+		if !submits() {
+			prepared, err := client.BuildSafeCreate()
+			if err != nil {
+				printError(err)
+				return
+			}
+
+			if err := emitPrepared(client, prepared); err != nil {
+				printError(err)
+			}
+			return
+		}
+
+		result, err := client.DeploySafeContext(cmd.Context())
+		if errors.Is(err, relayer.ErrSafeAlreadyDeployed) {
+			infof("Safe %s is already deployed\n", client.WalletAddress().Hex())
+			return
+		}
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		if err := printResult("Deploy safe "+client.WalletAddress().Hex()+" result", result); err != nil {
+			printError(err)
+			return
+		}
+
+		if wait {
+			waitForTransaction(cmd.Context(), client, result.TransactionID)
+		}
+	},
+}
+
+var walletInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show the EOA, Safe and proxy wallet addresses for a key",
+	Long: `Shows the signer (EOA) address and the Safe and proxy wallet addresses derived
+from it. The owner is taken from the configured signer unless --owner is
+given. When rpc_url is configured, deployment status and USDC and POL balances
+are read on chain.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		owner, err := walletOwnerAddress()
+		if err != nil {
+			printError(err)
+			return
+		}
+
+		accounts := []WalletAccount{
+			{Type: "EOA", Address: owner.Hex()},
+			{Type: "SAFE", Address: activeNetwork.DeriveSafe(owner).Hex()},
+		}
+		if activeNetwork.SupportsProxy() {
+			accounts = append(accounts, WalletAccount{Type: "PROXY", Address: activeNetwork.DeriveProxyWallet(owner).Hex()})
+		}
+
+		if config.AppCfg.RPCURL != "" {
+			if err := fillWalletAccounts(cmd.Context(), accounts); err != nil {
+				printError(err)
+				return
+			}
+		}
+
+		if err := render(accounts, walletOutput); err != nil {
+			printError(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(walletCmd)
+	walletCmd.AddCommand(walletDeployCmd)
+	walletCmd.AddCommand(walletInfoCmd)
+
+	walletInfoCmd.Flags().StringVar(&walletOwner, "owner", "", "Owner (EOA) address (default: address of the configured signer)")
+
+	addSigningFlags(walletDeployCmd)
+}
+
+func walletOwnerAddress() (common.Address, error) {
+	if walletOwner == "" {
+		return signerAddress()
+	}
+
+	if !common.IsHexAddress(walletOwner) {
+		return common.Address{}, usageError{fmt.Errorf("invalid owner address: %s", walletOwner)}
+	}
+
+	return common.HexToAddress(walletOwner), nil
+}
+
+// fillWalletAccounts reads deployment status and balances for each account
+// over the configured RPC endpoint.
+func fillWalletAccounts(ctx context.Context, accounts []WalletAccount) error {
+	chainClient, err := chain.NewClient(config.AppCfg.RPCURL)
+	if err != nil {
+		return err
+	}
+	defer chainClient.Close()
+
+	for i := range accounts {
+		account := &accounts[i]
+		address := common.HexToAddress(account.Address)
+
+		if account.Type != "EOA" {
+			deployed, err := chainClient.IsDeployed(ctx, address)
+			if err != nil {
+				return err
+			}
+			account.Deployed = &deployed
+		}
+
+		usdc, err := chainClient.BalanceOf(ctx, activeNetwork.USDC, address)
+		if err != nil {
+			return fmt.Errorf("failed to read USDC balance: %w", err)
+		}
+		account.USDC = formatBaseUnits(usdc)
+
+		pol, err := chainClient.Balance(ctx, address)
+		if err != nil {
+			return err
+		}
+		account.POL = formatUnits(pol, nativeDecimals)
+	}
+
+	return nil
+}
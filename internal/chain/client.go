@@ -0,0 +1,167 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const erc20ABI = `
+[{
+  "name": "balanceOf",
+  "type": "function",
+  "stateMutability": "view",
+  "inputs": [
+    { "name": "account", "type": "address" }
+  ],
+  "outputs": [
+    { "name": "", "type": "uint256" }
+  ]
+}, {
+  "name": "allowance",
+  "type": "function",
+  "stateMutability": "view",
+  "inputs": [
+    { "name": "owner", "type": "address" },
+    { "name": "spender", "type": "address" }
+  ],
+  "outputs": [
+    { "name": "", "type": "uint256" }
+  ]
+}]
+`
+
+const erc1155ABI = `
+[{
+  "name": "balanceOf",
+  "type": "function",
+  "stateMutability": "view",
+  "inputs": [
+    { "name": "account", "type": "address" },
+    { "name": "id", "type": "uint256" }
+  ],
+  "outputs": [
+    { "name": "", "type": "uint256" }
+  ]
+}, {
+  "name": "isApprovedForAll",
+  "type": "function",
+  "stateMutability": "view",
+  "inputs": [
+    { "name": "owner", "type": "address" },
+    { "name": "operator", "type": "address" }
+  ],
+  "outputs": [
+    { "name": "", "type": "bool" }
+  ]
+}]
+`
+
+// Client performs read-only contract calls against a JSON-RPC endpoint.
+type Client struct {
+	eth *ethclient.Client
+}
+
+func NewClient(rpcURL string) (*Client, error) {
+	eth, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RPC: %w", err)
+	}
+
+	return &Client{eth: eth}, nil
+}
+
+func (c *Client) Close() {
+	c.eth.Close()
+}
+
+// Allowance returns the ERC20 allowance granted by owner to spender.
+func (c *Client) Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error) {
+	var allowance *big.Int
+	if err := c.call(ctx, erc20ABI, token, "allowance", &allowance, owner, spender); err != nil {
+		return nil, err
+	}
+
+	return allowance, nil
+}
+
+// BalanceOf returns the ERC20 token balance of account.
+func (c *Client) BalanceOf(ctx context.Context, token, account common.Address) (*big.Int, error) {
+	var balance *big.Int
+	if err := c.call(ctx, erc20ABI, token, "balanceOf", &balance, account); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// TokenBalance returns the balance of account in the ERC1155 token id, such
+// as a CTF outcome token.
+func (c *Client) TokenBalance(ctx context.Context, token, account common.Address, id *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	if err := c.call(ctx, erc1155ABI, token, "balanceOf", &balance, account, id); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// Balance returns the native (POL) balance of account.
+func (c *Client) Balance(ctx context.Context, account common.Address) (*big.Int, error) {
+	balance, err := c.eth.BalanceAt(ctx, account, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// IsDeployed reports whether a contract is deployed at address.
+func (c *Client) IsDeployed(ctx context.Context, address common.Address) (bool, error) {
+	code, err := c.eth.CodeAt(ctx, address, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code: %w", err)
+	}
+
+	return len(code) > 0, nil
+}
+
+// IsApprovedForAll reports whether operator may transfer all of owner's
+// ERC1155 tokens.
+func (c *Client) IsApprovedForAll(ctx context.Context, token, owner, operator common.Address) (bool, error) {
+	var approved bool
+	if err := c.call(ctx, erc1155ABI, token, "isApprovedForAll", &approved, owner, operator); err != nil {
+		return false, err
+	}
+
+	return approved, nil
+}
+
+func (c *Client) call(ctx context.Context, contractABI string, to common.Address, method string, result any, args ...any) error {
+	parsedABI, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	output, err := c.eth.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	if err := parsedABI.UnpackIntoInterface(result, method, output); err != nil {
+		return fmt.Errorf("failed to unpack %s result: %w", method, err)
+	}
+
+	return nil
+}
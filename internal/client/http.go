@@ -1,12 +1,14 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"time"
+
+	"polymarket-cli/pkg/apierror"
 )
 
 type HTTPClient struct {
@@ -14,16 +16,35 @@ type HTTPClient struct {
 	httpClient *http.Client
 }
 
-func NewHTTPClient(baseURL string) *HTTPClient {
-	return &HTTPClient{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+// Option configures an HTTPClient created with NewHTTPClient.
+type Option func(*HTTPClient)
+
+// WithHTTPClient replaces the default client, which retries and rate limits
+// requests with DefaultPolicy.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *HTTPClient) {
+		c.httpClient = httpClient
 	}
 }
 
+func NewHTTPClient(baseURL string, opts ...Option) *HTTPClient {
+	c := &HTTPClient{
+		baseURL:    baseURL,
+		httpClient: NewRetryingClient(DefaultPolicy),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
 func (c *HTTPClient) Get(endpoint string, queryParams map[string]string) ([]byte, error) {
+	return c.GetContext(context.Background(), endpoint, queryParams)
+}
+
+func (c *HTTPClient) GetContext(ctx context.Context, endpoint string, queryParams map[string]string) ([]byte, error) {
 	reqURL, err := url.Parse(c.baseURL + endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
@@ -35,15 +56,19 @@ func (c *HTTPClient) Get(endpoint string, queryParams map[string]string) ([]byte
 	}
 	reqURL.RawQuery = query.Encode()
 
-	resp, err := c.httpClient.Get(reqURL.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute GET request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, apierror.New(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -55,7 +80,11 @@ func (c *HTTPClient) Get(endpoint string, queryParams map[string]string) ([]byte
 }
 
 func (c *HTTPClient) GetJSON(endpoint string, queryParams map[string]string, target any) error {
-	body, err := c.Get(endpoint, queryParams)
+	return c.GetJSONContext(context.Background(), endpoint, queryParams, target)
+}
+
+func (c *HTTPClient) GetJSONContext(ctx context.Context, endpoint string, queryParams map[string]string, target any) error {
+	body, err := c.GetContext(ctx, endpoint, queryParams)
 	if err != nil {
 		return err
 	}
@@ -68,6 +97,10 @@ func (c *HTTPClient) GetJSON(endpoint string, queryParams map[string]string, tar
 }
 
 func (c *HTTPClient) GetWithMultipleValues(endpoint string, queryParams url.Values) ([]byte, error) {
+	return c.GetWithMultipleValuesContext(context.Background(), endpoint, queryParams)
+}
+
+func (c *HTTPClient) GetWithMultipleValuesContext(ctx context.Context, endpoint string, queryParams url.Values) ([]byte, error) {
 	reqURL, err := url.Parse(c.baseURL + endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
@@ -75,15 +108,19 @@ func (c *HTTPClient) GetWithMultipleValues(endpoint string, queryParams url.Valu
 
 	reqURL.RawQuery = queryParams.Encode()
 
-	resp, err := c.httpClient.Get(reqURL.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute GET request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, apierror.New(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -95,7 +132,11 @@ func (c *HTTPClient) GetWithMultipleValues(endpoint string, queryParams url.Valu
 }
 
 func (c *HTTPClient) GetJSONWithMultipleValues(endpoint string, queryParams url.Values, target any) error {
-	body, err := c.GetWithMultipleValues(endpoint, queryParams)
+	return c.GetJSONWithMultipleValuesContext(context.Background(), endpoint, queryParams, target)
+}
+
+func (c *HTTPClient) GetJSONWithMultipleValuesContext(ctx context.Context, endpoint string, queryParams url.Values, target any) error {
+	body, err := c.GetWithMultipleValuesContext(ctx, endpoint, queryParams)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+package client
+
+import "context"
+
+// PageFunc fetches up to limit items starting at offset.
+type PageFunc[T any] func(ctx context.Context, offset, limit int) ([]T, error)
+
+// PageOptions configures a Paginator. MaxOffset is the largest offset the
+// endpoint accepts; zero means no cap.
+type PageOptions struct {
+	Offset    int
+	Limit     int
+	MaxOffset int
+}
+
+// Paginator walks an offset/limit endpoint one page at a time until a short
+// page is returned or the offset cap is reached:
+//
+//	p := client.NewPaginator(fetch, client.PageOptions{Limit: 500, MaxOffset: 10000})
+//	for p.Next(ctx) {
+//		handle(p.Page())
+//	}
+//	if err := p.Err(); err != nil { ... }
+type Paginator[T any] struct {
+	fetch     PageFunc[T]
+	opts      PageOptions
+	offset    int
+	page      []T
+	err       error
+	done      bool
+	truncated bool
+}
+
+func NewPaginator[T any](fetch PageFunc[T], opts PageOptions) *Paginator[T] {
+	if opts.Limit <= 0 {
+		opts.Limit = 100
+	}
+
+	return &Paginator[T]{
+		fetch:  fetch,
+		opts:   opts,
+		offset: opts.Offset,
+	}
+}
+
+// Next fetches the next page and reports whether it holds any items.
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	p.page = nil
+	if p.done {
+		return false
+	}
+
+	if p.opts.MaxOffset > 0 && p.offset > p.opts.MaxOffset {
+		p.done = true
+		p.truncated = true
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.offset, p.opts.Limit)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	p.offset += len(page)
+	if len(page) < p.opts.Limit {
+		p.done = true
+	}
+
+	p.page = page
+	return len(page) > 0
+}
+
+// Page returns the items fetched by the last call to Next.
+func (p *Paginator[T]) Page() []T {
+	return p.page
+}
+
+// Offset returns the offset of the next page.
+func (p *Paginator[T]) Offset() int {
+	return p.offset
+}
+
+// Err returns the error that stopped pagination, if any.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// Truncated reports whether pagination stopped at MaxOffset while more items
+// may remain.
+func (p *Paginator[T]) Truncated() bool {
+	return p.truncated
+}
+
+// All collects every remaining item. Use Truncated to check whether the
+// offset cap cut the result short.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.Next(ctx) {
+		all = append(all, p.Page()...)
+	}
+	return all, p.Err()
+}
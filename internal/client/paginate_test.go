@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// pages serves items from a slice of n elements and records the offsets
+// requested.
+func pages(n int, offsets *[]int) PageFunc[int] {
+	return func(ctx context.Context, offset, limit int) ([]int, error) {
+		*offsets = append(*offsets, offset)
+
+		var page []int
+		for i := offset; i < n && len(page) < limit; i++ {
+			page = append(page, i)
+		}
+		return page, nil
+	}
+}
+
+func TestPaginatorStopsAtShortPage(t *testing.T) {
+	var offsets []int
+	p := NewPaginator(pages(5, &offsets), PageOptions{Limit: 2})
+
+	all, err := p.All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(all, want) {
+		t.Errorf("items = %v, want %v", all, want)
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("offsets = %v, want %v", offsets, want)
+	}
+	if p.Truncated() {
+		t.Error("truncated, want complete")
+	}
+}
+
+func TestPaginatorStopsAtEmptyPage(t *testing.T) {
+	var offsets []int
+	p := NewPaginator(pages(4, &offsets), PageOptions{Offset: 1, Limit: 3})
+
+	all, err := p.All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(all, want) {
+		t.Errorf("items = %v, want %v", all, want)
+	}
+	if want := []int{1, 4}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("offsets = %v, want %v", offsets, want)
+	}
+	if p.Offset() != 4 || p.Truncated() {
+		t.Errorf("offset = %d, truncated = %t; want 4, false", p.Offset(), p.Truncated())
+	}
+}
+
+func TestPaginatorTruncatesAtMaxOffset(t *testing.T) {
+	var offsets []int
+	p := NewPaginator(pages(100, &offsets), PageOptions{Limit: 2, MaxOffset: 4})
+
+	all, err := p.All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != 6 {
+		t.Errorf("got %d items, want 6", len(all))
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("offsets = %v, want %v", offsets, want)
+	}
+	if !p.Truncated() {
+		t.Error("not truncated, want truncated at the offset cap")
+	}
+}
+
+func TestPaginatorStopsOnError(t *testing.T) {
+	errPage := errors.New("boom")
+	calls := 0
+	fetch := func(ctx context.Context, offset, limit int) ([]int, error) {
+		calls++
+		if offset > 0 {
+			return nil, errPage
+		}
+		return []int{1, 2}, nil
+	}
+
+	p := NewPaginator(fetch, PageOptions{Limit: 2})
+	all, err := p.All(context.Background())
+	if !errors.Is(err, errPage) {
+		t.Errorf("err = %v, want %v", err, errPage)
+	}
+	if len(all) != 2 || calls != 2 {
+		t.Errorf("got %d items after %d calls, want 2 after 2", len(all), calls)
+	}
+	if p.Next(context.Background()) || calls != 2 {
+		t.Error("Next fetched again after an error")
+	}
+}
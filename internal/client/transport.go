@@ -0,0 +1,272 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"polymarket-cli/pkg/apierror"
+)
+
+var errNoRewind = errors.New("cannot retry request: body cannot be rewound")
+
+// maxDrainBody caps how much of a response that is retried is read before
+// closing it, so the connection can be reused.
+const maxDrainBody = 64 << 10
+
+// maxRetryAfter caps how long a server-provided Retry-After is honored.
+const maxRetryAfter = 2 * time.Minute
+
+// Policy configures retries and rate limiting of a Transport.
+type Policy struct {
+	// Timeout bounds each attempt, not the request as a whole. Zero means
+	// no timeout.
+	Timeout time.Duration
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// attempts when the server gives no Retry-After.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RateLimit is the sustained number of requests per second allowed to
+	// each host, with bursts of up to Burst requests. Zero disables it.
+	RateLimit float64
+	Burst     int
+}
+
+var DefaultPolicy = Policy{
+	Timeout:    30 * time.Second,
+	MaxRetries: 3,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+	RateLimit:  10,
+	Burst:      10,
+}
+
+// Transport is an http.RoundTripper that rate limits requests per host and
+// retries failed ones with exponential backoff and jitter, honoring
+// Retry-After. GET and HEAD requests are retried on network errors, 429 and
+// 5xx responses; other methods only on 429, since the server may already
+// have acted on them otherwise.
+type Transport struct {
+	base   http.RoundTripper
+	policy Policy
+
+	mu       sync.Mutex
+	limiters map[string]*tokenBucket
+}
+
+// NewTransport wraps base, or http.DefaultTransport when base is nil.
+func NewTransport(base http.RoundTripper, policy Policy) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		base:     base,
+		policy:   policy,
+		limiters: make(map[string]*tokenBucket),
+	}
+}
+
+// NewRetryingClient returns an http.Client using a Transport with policy.
+func NewRetryingClient(policy Policy) *http.Client {
+	return &http.Client{Transport: NewTransport(nil, policy)}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter(req.URL.Host).wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq, err := rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.roundTrip(attemptReq)
+		if attempt >= t.policy.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter := apierror.ParseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > 0 {
+				delay = min(retryAfter, maxRetryAfter)
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTrip sends a single attempt, bounded by the policy timeout. The
+// timeout stays in force until the response body is closed.
+func (t *Transport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.policy.Timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.policy.Timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (t *Transport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	if err != nil {
+		return idempotent
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return idempotent && resp.StatusCode >= 500
+}
+
+// backoff returns the delay before retry attempt+1: exponential from
+// MinBackoff, capped at MaxBackoff, with jitter over its upper half.
+func (t *Transport) backoff(attempt int) time.Duration {
+	delay := t.policy.MinBackoff << attempt
+	if delay <= 0 || delay > t.policy.MaxBackoff {
+		delay = t.policy.MaxBackoff
+	}
+
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+
+	return half + rand.N(half)
+}
+
+func (t *Transport) limiter(host string) *tokenBucket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limiter, ok := t.limiters[host]
+	if !ok {
+		limiter = newTokenBucket(t.policy.RateLimit, t.policy.Burst)
+		t.limiters[host] = limiter
+	}
+
+	return limiter
+}
+
+// rewind returns req ready to be sent for the given attempt, with a fresh
+// copy of its body on retries.
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errNoRewind
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// tokenBucket allows rate requests per second with bursts of up to burst.
+// A nil bucket never blocks.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	deficit := -b.tokens
+	b.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / b.rate * float64(time.Second)))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testPolicy retries quickly and does not rate limit.
+var testPolicy = Policy{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 5 * time.Millisecond,
+}
+
+// statusServer answers with statuses in turn, then 200 once they run out,
+// and counts the requests it receives.
+func statusServer(t *testing.T, calls *atomic.Int32, statuses ...int) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTransportRetriesGETOn5xx(t *testing.T) {
+	var calls atomic.Int32
+	srv := statusServer(t, &calls, http.StatusBadGateway, http.StatusServiceUnavailable)
+
+	resp, err := NewRetryingClient(testPolicy).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestTransportGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := statusServer(t, &calls, 500, 500, 500, 500, 500)
+
+	policy := testPolicy
+	policy.MaxRetries = 2
+
+	resp, err := NewRetryingClient(policy).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestTransportDoesNotRetryPOSTOn5xx(t *testing.T) {
+	var calls atomic.Int32
+	srv := statusServer(t, &calls, http.StatusInternalServerError)
+
+	resp, err := NewRetryingClient(testPolicy).Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestTransportRetriesPOSTOn429WithBody(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"a":1}` {
+			t.Errorf("attempt %d body = %q", calls.Load()+1, body)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := NewRetryingClient(testPolicy).Post(srv.URL, "application/json", strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("status = %d after %d attempts, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestTransportHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	// Without Retry-After the backoff would be an hour.
+	policy := testPolicy
+	policy.MinBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := NewRetryingClient(policy).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond || elapsed > 5*time.Second {
+		t.Errorf("retried after %s, want about 1s", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("status = %d after %d attempts, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestTransportStopsRetryingWhenCancelled(t *testing.T) {
+	var calls atomic.Int32
+	srv := statusServer(t, &calls, 503, 503, 503, 503)
+
+	policy := testPolicy
+	policy.MinBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewRetryingClient(policy).Do(req); err == nil {
+		t.Fatal("request succeeded, want context error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	if newTokenBucket(0, 5) != nil {
+		t.Error("zero rate should disable the limiter")
+	}
+
+	bucket := newTokenBucket(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 2 {
+		if err := bucket.wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("burst of 2 took %s, want no wait", elapsed)
+	}
+
+	start = time.Now()
+	if err := bucket.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("third request waited %s, want about 50ms at 20/s", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := bucket.wait(cancelled); err == nil {
+		t.Error("wait on an empty bucket with a cancelled context succeeded")
+	}
+}
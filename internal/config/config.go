@@ -1,35 +1,376 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"polymarket-cli/internal/client"
 )
 
+// EnvPrefix prefixes the environment variables that override config keys,
+// e.g. POLYMARKET_BUILDER_API_KEY for builder.api_key.
+const EnvPrefix = "POLYMARKET"
+
 type BuilderConfig struct {
-	APIKey     string `mapstructure:"api_key"`
-	Passphrase string `mapstructure:"passphrase"`
-	APISecret  string `mapstructure:"api_secret"`
+	APIKey     string `mapstructure:"api_key" json:"api_key,omitempty"`
+	Passphrase string `mapstructure:"passphrase" json:"passphrase,omitempty"`
+	APISecret  string `mapstructure:"api_secret" json:"api_secret,omitempty"`
+}
+
+// SignerConfig selects how relayer requests are signed. At most one of
+// Keystore and External is expected; Key and PrivateKey on Config are the
+// fallbacks.
+type SignerConfig struct {
+	Keystore string `mapstructure:"keystore" json:"keystore,omitempty"`
+	External string `mapstructure:"external" json:"external,omitempty"`
+	Address  string `mapstructure:"address" json:"address,omitempty"`
+}
+
+// ContractsConfig overrides contract addresses and init code hashes of the
+// selected network, e.g. for a local fork. Empty values keep the preset.
+type ContractsConfig struct {
+	CTF               string `mapstructure:"ctf" json:"ctf,omitempty"`
+	USDC              string `mapstructure:"usdc" json:"usdc,omitempty"`
+	Exchange          string `mapstructure:"exchange" json:"exchange,omitempty"`
+	NegRiskExchange   string `mapstructure:"neg_risk_exchange" json:"neg_risk_exchange,omitempty"`
+	NegRiskAdapter    string `mapstructure:"neg_risk_adapter" json:"neg_risk_adapter,omitempty"`
+	SafeFactory       string `mapstructure:"safe_factory" json:"safe_factory,omitempty"`
+	SafeMultisend     string `mapstructure:"safe_multisend" json:"safe_multisend,omitempty"`
+	SafeInitCodeHash  string `mapstructure:"safe_init_code_hash" json:"safe_init_code_hash,omitempty"`
+	ProxyFactory      string `mapstructure:"proxy_factory" json:"proxy_factory,omitempty"`
+	RelayHub          string `mapstructure:"relay_hub" json:"relay_hub,omitempty"`
+	ProxyInitCodeHash string `mapstructure:"proxy_init_code_hash" json:"proxy_init_code_hash,omitempty"`
+}
+
+// HTTPConfig tunes retries and per-host rate limiting of requests to the
+// data API and the relayer.
+type HTTPConfig struct {
+	Timeout    time.Duration `mapstructure:"timeout" json:"timeout"`
+	MaxRetries int           `mapstructure:"max_retries" json:"max_retries"`
+	MinBackoff time.Duration `mapstructure:"min_backoff" json:"min_backoff"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff" json:"max_backoff"`
+	RateLimit  float64       `mapstructure:"rate_limit" json:"rate_limit"`
+	Burst      int           `mapstructure:"burst" json:"burst"`
 }
 
 type Config struct {
-	Builder        BuilderConfig `mapstructure:"builder"`
-	DataAPIBaseURL string        `mapstructure:"data_api_base_url"`
-	PrivateKey     string        `mapstructure:"private_key"`
+	Profile        string          `mapstructure:"profile" json:"profile,omitempty"`
+	Builder        BuilderConfig   `mapstructure:"builder" json:"builder,omitempty"`
+	Signer         SignerConfig    `mapstructure:"signer" json:"signer,omitempty"`
+	DataAPIBaseURL string          `mapstructure:"data_api_base_url" json:"data_api_base_url,omitempty"`
+	Network        string          `mapstructure:"network" json:"network,omitempty"`
+	ChainID        int64           `mapstructure:"chain_id" json:"chain_id,omitempty"`
+	RelayerURL     string          `mapstructure:"relayer_url" json:"relayer_url,omitempty"`
+	Contracts      ContractsConfig `mapstructure:"contracts" json:"contracts,omitempty"`
+	TxType         string          `mapstructure:"tx_type" json:"tx_type,omitempty"`
+	PrivateKey     string          `mapstructure:"private_key" json:"private_key,omitempty"`
+	Key            string          `mapstructure:"key" json:"key,omitempty"`
+	KeystoreDir    string          `mapstructure:"keystore_dir" json:"keystore_dir,omitempty"`
+	RPCURL         string          `mapstructure:"rpc_url" json:"rpc_url,omitempty"`
+	OutputFormat   string          `mapstructure:"output_format" json:"output_format,omitempty"`
+	HTTP           HTTPConfig      `mapstructure:"http" json:"http"`
 }
 
 var AppCfg *Config
 
-func Init() {
-	AppCfg = &Config{
+// Init resolves AppCfg for the profile selected with --profile,
+// POLYMARKET_PROFILE or the profile key of the config file.
+func Init() error {
+	cfg, err := Resolve(viper.GetString("profile"))
+	if err != nil {
+		return err
+	}
+
+	AppCfg = cfg
+	return nil
+}
+
+// Resolve builds the configuration for profile. Each key is looked up in the
+// environment, then under profiles.<profile>, then at the top level of the
+// config file. An empty profile uses the top level only.
+func Resolve(profile string) (*Config, error) {
+	if profile != "" && !viper.IsSet("profiles."+profile) {
+		return nil, fmt.Errorf("profile %q not found in config", profile)
+	}
+
+	get := func(key string) string {
+		return lookup(profile, key)
+	}
+
+	cfg := &Config{
+		Profile: profile,
 		Builder: BuilderConfig{
-			APIKey:     viper.GetString("builder.api_key"),
-			Passphrase: viper.GetString("builder.passphrase"),
-			APISecret:  viper.GetString("builder.api_secret"),
+			APIKey:     get("builder.api_key"),
+			Passphrase: get("builder.passphrase"),
+			APISecret:  get("builder.api_secret"),
+		},
+		Signer: SignerConfig{
+			Keystore: get("signer.keystore"),
+			External: get("signer.external"),
+			Address:  get("signer.address"),
 		},
-		DataAPIBaseURL: viper.GetString("data_api_base_url"),
-		PrivateKey:     viper.GetString("private_key"),
+		DataAPIBaseURL: get("data_api_base_url"),
+		Network:        get("network"),
+		RelayerURL:     get("relayer_url"),
+		Contracts: ContractsConfig{
+			CTF:               get("contracts.ctf"),
+			USDC:              get("contracts.usdc"),
+			Exchange:          get("contracts.exchange"),
+			NegRiskExchange:   get("contracts.neg_risk_exchange"),
+			NegRiskAdapter:    get("contracts.neg_risk_adapter"),
+			SafeFactory:       get("contracts.safe_factory"),
+			SafeMultisend:     get("contracts.safe_multisend"),
+			SafeInitCodeHash:  get("contracts.safe_init_code_hash"),
+			ProxyFactory:      get("contracts.proxy_factory"),
+			RelayHub:          get("contracts.relay_hub"),
+			ProxyInitCodeHash: get("contracts.proxy_init_code_hash"),
+		},
+		TxType:       strings.ToUpper(get("tx_type")),
+		PrivateKey:   get("private_key"),
+		Key:          get("key"),
+		KeystoreDir:  get("keystore_dir"),
+		RPCURL:       get("rpc_url"),
+		OutputFormat: get("output_format"),
+	}
+
+	if chainID := get("chain_id"); chainID != "" {
+		parsed, err := strconv.ParseInt(chainID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain_id: %s", chainID)
+		}
+		cfg.ChainID = parsed
+	}
+
+	if err := resolveHTTP(&cfg.HTTP, get); err != nil {
+		return nil, err
+	}
+
+	if cfg.DataAPIBaseURL == "" {
+		cfg.DataAPIBaseURL = "https://data-api.polymarket.com"
+	}
+
+	if cfg.KeystoreDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			cfg.KeystoreDir = filepath.Join(home, ".polymarket-cli", "keystore")
+		}
+	}
+
+	return cfg, nil
+}
+
+func resolveHTTP(http *HTTPConfig, get func(string) string) error {
+	defaults := client.DefaultPolicy
+	*http = HTTPConfig{
+		Timeout:    defaults.Timeout,
+		MaxRetries: defaults.MaxRetries,
+		MinBackoff: defaults.MinBackoff,
+		MaxBackoff: defaults.MaxBackoff,
+		RateLimit:  defaults.RateLimit,
+		Burst:      defaults.Burst,
+	}
+
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"http.timeout", &http.Timeout},
+		{"http.min_backoff", &http.MinBackoff},
+		{"http.max_backoff", &http.MaxBackoff},
+	}
+	for _, d := range durations {
+		if value := get(d.key); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", d.key, value)
+			}
+			*d.dst = parsed
+		}
+	}
+
+	ints := []struct {
+		key string
+		dst *int
+	}{
+		{"http.max_retries", &http.MaxRetries},
+		{"http.burst", &http.Burst},
+	}
+	for _, i := range ints {
+		if value := get(i.key); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				return fmt.Errorf("invalid %s: %s", i.key, value)
+			}
+			*i.dst = parsed
+		}
+	}
+
+	if value := get("http.rate_limit"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("invalid http.rate_limit: %s", value)
+		}
+		http.RateLimit = parsed
 	}
 
-	if AppCfg.DataAPIBaseURL == "" {
-		AppCfg.DataAPIBaseURL = "https://data-api.polymarket.com"
+	return nil
+}
+
+func lookup(profile, key string) string {
+	if value, ok := os.LookupEnv(EnvName(key)); ok {
+		return value
+	}
+
+	if profile != "" {
+		if profileKey := "profiles." + profile + "." + key; viper.IsSet(profileKey) {
+			return viper.GetString(profileKey)
+		}
+	}
+
+	return viper.GetString(key)
+}
+
+// EnvName returns the environment variable that overrides key.
+func EnvName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Profiles returns the names of the profiles defined in the config file.
+func Profiles() []string {
+	var names []string
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetDefaultProfile sets the top-level profile key in the config file at
+// path, creating the file if needed. The file is edited in place: only the
+// text of the profile value changes, or a "profile: <name>" line is appended
+// when the key is missing, so formatting and comments are kept.
+func SetDefaultProfile(path, name string) error {
+	perm := os.FileMode(0o600)
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if info, statErr := os.Stat(path); statErr == nil {
+			perm = info.Mode().Perm()
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	updated, err := setProfileKey(data, name)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+
+	return os.WriteFile(path, updated, perm)
+}
+
+// setProfileKey returns data with the top-level profile value replaced by
+// name, or with a profile line appended when there is none.
+func setProfileKey(data []byte, name string) ([]byte, error) {
+	encoded, err := yaml.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+	value := bytes.TrimSuffix(encoded, []byte("\n"))
+	if bytes.ContainsRune(value, '\n') {
+		return nil, fmt.Errorf("invalid profile name %q", name)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return appendLine(data, "profile: "+string(value)), nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode || root.Style&yaml.FlowStyle != 0 {
+		return nil, errors.New("top level is not a block mapping")
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, old := root.Content[i], root.Content[i+1]
+		if key.Value != "profile" {
+			continue
+		}
+		if old.Kind != yaml.ScalarNode || old.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			return nil, errors.New("profile is not a single-line string")
+		}
+
+		lines := bytes.SplitAfter(data, []byte("\n"))
+		if old.Tag == "!!null" && old.Value == "" {
+			// "profile:" with no value: write it after the colon.
+			line := lines[key.Line-1]
+			start := columnOffset(line, key.Column)
+			colon := bytes.IndexByte(line[start:], ':')
+			if colon < 0 {
+				return nil, errors.New("profile key has no value")
+			}
+			at := start + colon + 1
+			lines[key.Line-1] = splice(line, at, at, append([]byte(" "), value...))
+			return bytes.Join(lines, nil), nil
+		}
+		if old.Line != key.Line {
+			return nil, errors.New("profile value must be on the same line as its key")
+		}
+
+		line := lines[old.Line-1]
+		from := columnOffset(line, old.Column)
+		to := len(bytes.TrimRight(line, "\r\n"))
+		if old.LineComment != "" {
+			if at := bytes.LastIndex(line, []byte(old.LineComment)); at > from {
+				to = at
+			}
+		}
+		for to > from && (line[to-1] == ' ' || line[to-1] == '\t') {
+			to--
+		}
+		lines[old.Line-1] = splice(line, from, to, value)
+		return bytes.Join(lines, nil), nil
+	}
+
+	return appendLine(data, strings.Repeat(" ", root.Column-1)+"profile: "+string(value)), nil
+}
+
+// columnOffset converts a 1-based yaml column, counted in characters, to a
+// byte offset in line.
+func columnOffset(line []byte, column int) int {
+	offset := 0
+	for n := 1; n < column && offset < len(line); n++ {
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func splice(line []byte, from, to int, value []byte) []byte {
+	out := make([]byte, 0, len(line)-(to-from)+len(value))
+	out = append(out, line[:from]...)
+	out = append(out, value...)
+	return append(out, line[to:]...)
+}
+
+func appendLine(data []byte, line string) []byte {
+	out := append([]byte{}, data...)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		out = append(out, '\n')
 	}
+	return append(out, line+"\n"...)
 }
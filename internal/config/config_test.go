@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetProfileKey(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "replace keeps formatting",
+			in: "# polymarket-cli\n" +
+				"profile: 'dev'   # default profile\n" +
+				"profiles:\n" +
+				"  dev: {output_format: csv}\n" +
+				"  prod:\n" +
+				"    rpc_url: \"https://polygon-rpc.com\"\n",
+			want: "# polymarket-cli\n" +
+				"profile: prod   # default profile\n" +
+				"profiles:\n" +
+				"  dev: {output_format: csv}\n" +
+				"  prod:\n" +
+				"    rpc_url: \"https://polygon-rpc.com\"\n",
+		},
+		{
+			name: "replace null value",
+			in:   "profile:\noutput_format: table\n",
+			want: "profile: prod\noutput_format: table\n",
+		},
+		{
+			name: "append when missing",
+			in:   "output_format: table",
+			want: "output_format: table\nprofile: prod\n",
+		},
+		{
+			name: "empty file",
+			in:   "",
+			want: "profile: prod\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setProfileKey([]byte(tt.in), "prod")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetProfileKeyQuotes(t *testing.T) {
+	got, err := setProfileKey([]byte("profile: dev\n"), "yes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "profile: \"yes\"\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetProfileKeyErrors(t *testing.T) {
+	for _, in := range []string{
+		"{profile: dev}\n",
+		"profile:\n  name: dev\n",
+		"profile: |\n  dev\n",
+	} {
+		if _, err := setProfileKey([]byte(in), "prod"); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
+
+func TestSetDefaultProfileCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := SetDefaultProfile(path, "prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "profile: prod\n" {
+		t.Errorf("got %q", data)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("got mode %o, want 600", perm)
+	}
+}
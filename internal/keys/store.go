@@ -0,0 +1,175 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrKeyNotFound = errors.New("key not found")
+	ErrKeyExists   = errors.New("key already exists")
+
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// Key is a keystore file in the store. Name is the file name without the
+// .json extension.
+type Key struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Path    string `json:"path"`
+}
+
+// Store keeps go-ethereum v3 keystore files, one <name>.json per key, in a
+// single directory.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Create encrypts privateKey with passphrase and stores it under name.
+func (s *Store) Create(name string, privateKey *ecdsa.PrivateKey, passphrase string) (*Key, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid key name %q: use letters, digits, '-' and '_'", name)
+	}
+
+	path := s.path(name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyExists, name)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, keyJSON, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write keystore file: %w", err)
+	}
+
+	return &Key{
+		Name:    name,
+		Address: key.Address.Hex(),
+		Path:    path,
+	}, nil
+}
+
+// List returns every key in the store sorted by name.
+func (s *Store) List() ([]Key, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore directory: %w", err)
+	}
+
+	var keys []Key
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(s.dir, entry.Name())
+		address, err := ReadAddress(path)
+		if err != nil {
+			continue
+		}
+
+		keys = append(keys, Key{
+			Name:    strings.TrimSuffix(entry.Name(), ".json"),
+			Address: address.Hex(),
+			Path:    path,
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+
+	return keys, nil
+}
+
+// Find returns the key with the given name or address.
+func (s *Store) Find(nameOrAddress string) (*Key, error) {
+	keys, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if key.Name == nameOrAddress {
+			return &key, nil
+		}
+	}
+
+	if common.IsHexAddress(nameOrAddress) {
+		address := common.HexToAddress(nameOrAddress)
+		for _, key := range keys {
+			if common.HexToAddress(key.Address) == address {
+				return &key, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s in %s", ErrKeyNotFound, nameOrAddress, s.dir)
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// ReadAddress returns the address recorded in a keystore file without
+// decrypting it.
+func ReadAddress(path string) (common.Address, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return common.Address{}, fmt.Errorf("invalid keystore file %s: %w", path, err)
+	}
+	if !common.IsHexAddress(key.Address) {
+		return common.Address{}, fmt.Errorf("invalid address in keystore file %s", path)
+	}
+
+	return common.HexToAddress(key.Address), nil
+}
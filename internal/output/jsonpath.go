@@ -0,0 +1,228 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a small subset of kubectl's JSONPath: text with {...}
+// expressions made of .field, [index] and [*] steps, and quoted literals
+// such as {"\t"}. Field names match JSON keys, case-insensitively when there
+// is no exact match. Missing fields print as empty strings.
+type jsonPath struct {
+	parts []pathPart
+}
+
+type pathPart struct {
+	text  string
+	steps []pathStep
+	expr  bool
+}
+
+type pathStep struct {
+	field string
+	index int
+	all   bool
+	isIdx bool
+}
+
+func parseJSONPath(s string) (*jsonPath, error) {
+	if !strings.Contains(s, "{") {
+		s = "{" + s + "}"
+	}
+
+	p := &jsonPath{}
+	for s != "" {
+		start := strings.Index(s, "{")
+		if start < 0 {
+			p.parts = append(p.parts, pathPart{text: s})
+			break
+		}
+		if start > 0 {
+			p.parts = append(p.parts, pathPart{text: s[:start]})
+		}
+
+		end := closingBrace(s[start:])
+		if end < 0 {
+			return nil, fmt.Errorf("invalid jsonpath %q: unclosed {", s)
+		}
+		part, err := parseExpression(strings.TrimSpace(s[start+1 : start+end]))
+		if err != nil {
+			return nil, err
+		}
+		p.parts = append(p.parts, part)
+		s = s[start+end+1:]
+	}
+
+	return p, nil
+}
+
+// closingBrace returns the index of the "}" that closes the expression
+// opening s, skipping braces inside quoted literals, or -1.
+func closingBrace(s string) int {
+	quoted := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseExpression(expr string) (pathPart, error) {
+	if strings.HasPrefix(expr, `"`) {
+		text, err := strconv.Unquote(expr)
+		if err != nil {
+			return pathPart{}, fmt.Errorf("invalid jsonpath literal %s: %w", expr, err)
+		}
+		return pathPart{text: text}, nil
+	}
+
+	expr = strings.TrimPrefix(expr, "$")
+	part := pathPart{expr: true}
+	for expr != "" {
+		switch expr[0] {
+		case '.':
+			expr = expr[1:]
+			end := strings.IndexAny(expr, ".[")
+			if end < 0 {
+				end = len(expr)
+			}
+			if end > 0 {
+				part.steps = append(part.steps, pathStep{field: expr[:end]})
+			}
+			expr = expr[end:]
+		case '[':
+			end := strings.Index(expr, "]")
+			if end < 0 {
+				return pathPart{}, fmt.Errorf("invalid jsonpath %q: unclosed [", expr)
+			}
+			inner := strings.TrimSpace(expr[1:end])
+			if inner == "*" {
+				part.steps = append(part.steps, pathStep{all: true})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return pathPart{}, fmt.Errorf("invalid jsonpath index [%s]", inner)
+				}
+				part.steps = append(part.steps, pathStep{index: index, isIdx: true})
+			}
+			expr = expr[end+1:]
+		default:
+			return pathPart{}, fmt.Errorf("invalid jsonpath expression %q: expected . or [", expr)
+		}
+	}
+
+	return part, nil
+}
+
+// execute evaluates the path against an item's JSON encoding. Multiple
+// results from [*] are joined with spaces.
+func (p *jsonPath) execute(data []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return "", fmt.Errorf("failed to format output: %w", err)
+	}
+
+	var b strings.Builder
+	for _, part := range p.parts {
+		if !part.expr {
+			b.WriteString(part.text)
+			continue
+		}
+
+		values := []any{root}
+		for _, step := range part.steps {
+			values = step.apply(values)
+		}
+
+		texts := make([]string, 0, len(values))
+		for _, v := range values {
+			text, err := pathText(v)
+			if err != nil {
+				return "", err
+			}
+			texts = append(texts, text)
+		}
+		b.WriteString(strings.Join(texts, " "))
+	}
+
+	return b.String(), nil
+}
+
+func (step pathStep) apply(values []any) []any {
+	var next []any
+	for _, v := range values {
+		switch {
+		case step.all:
+			switch v := v.(type) {
+			case []any:
+				next = append(next, v...)
+			case map[string]any:
+				for _, key := range slices.Sorted(maps.Keys(v)) {
+					next = append(next, v[key])
+				}
+			}
+		case step.isIdx:
+			list, ok := v.([]any)
+			if !ok {
+				continue
+			}
+			index := step.index
+			if index < 0 {
+				index += len(list)
+			}
+			if index >= 0 && index < len(list) {
+				next = append(next, list[index])
+			}
+		default:
+			obj, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			if value, ok := obj[step.field]; ok {
+				next = append(next, value)
+				continue
+			}
+			for key, value := range obj {
+				if strings.EqualFold(key, step.field) {
+					next = append(next, value)
+					break
+				}
+			}
+		}
+	}
+	return next
+}
+
+func pathText(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to format output: %w", err)
+		}
+		return string(data), nil
+	}
+}
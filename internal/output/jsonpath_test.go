@@ -0,0 +1,65 @@
+package output
+
+import "testing"
+
+func TestJSONPath(t *testing.T) {
+	data := []byte(`{"a":"x","b":2.5,"tags":["p","q"],"market":{"title":"T"}}`)
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`.a`, "x"},
+		{`{.a}{"\t"}{.b}`, "x\t2.5"},
+		{`{.a}{"}"}{.b}`, "x}2.5"},
+		{`{.a}{"{"}{.b}`, "x{2.5"},
+		{`{.a}{"\"}"}{.b}`, `x"}2.5`},
+		{`{.a} has {.tags[*]}`, "x has p q"},
+		{`{.tags[-1]}`, "q"},
+		{`{.market.Title}`, "T"},
+		{`{.missing}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			path, err := parseJSONPath(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := path.execute(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPathErrors(t *testing.T) {
+	for _, expr := range []string{`{.a`, `{"}`, `{.a[x]}`, `{a}`} {
+		if _, err := parseJSONPath(expr); err == nil {
+			t.Errorf("%s parsed without error", expr)
+		}
+	}
+}
+
+func TestClosingBrace(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{`{.a}`, 3},
+		{`{"}"}{.b}`, 4},
+		{`{"\"}"}`, 6},
+		{`{"}`, -1},
+	}
+
+	for _, tt := range tests {
+		if got := closingBrace(tt.s); got != tt.want {
+			t.Errorf("closingBrace(%s) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
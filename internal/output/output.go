@@ -0,0 +1,483 @@
+// Package output renders command results as JSON, NDJSON, CSV or an aligned
+// table, with column selection and sorting, or one line per item from a Go
+// template or JSONPath expression.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+type Format string
+
+const (
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+	Table  Format = "table"
+	// Template and JSONPath print one line per item; their expression
+	// follows "=" in the format name.
+	Template Format = "template"
+	JSONPath Format = "jsonpath"
+)
+
+var Formats = []Format{JSON, NDJSON, CSV, Table, Template, JSONPath}
+
+// ParseFormat parses an --output value such as "table",
+// "template={{.Title}}" or "jsonpath={.conditionId}" into the format and
+// its expression.
+func ParseFormat(s string) (Format, string, error) {
+	name, expr, hasExpr := strings.Cut(s, "=")
+	format := Format(strings.ToLower(name))
+
+	switch {
+	case format == Template || format == JSONPath:
+		if expr == "" {
+			return "", "", fmt.Errorf("%s output needs an expression, e.g. -o %s", format, example(format))
+		}
+		return format, expr, nil
+	case hasExpr:
+		return "", "", fmt.Errorf("output format %q does not take an expression", name)
+	case slices.Contains(Formats, format):
+		return format, "", nil
+	}
+
+	return "", "", fmt.Errorf("unknown output format %q (expected json, ndjson, csv, table, template=... or jsonpath=...)", s)
+}
+
+func example(format Format) string {
+	if format == Template {
+		return "template='{{.Title}} {{.Size}}'"
+	}
+	return "jsonpath='{.conditionId}'"
+}
+
+type Options struct {
+	Format Format
+	// Expression is the Go template or JSONPath for those formats. Templates
+	// see the original value, so they use Go field names ({{.CashPnl}});
+	// JSONPath uses JSON keys ({.cashPnl}).
+	Expression string
+	// Columns selects and orders the columns to print; matching is case
+	// insensitive and nested fields use dotted names. Empty prints all
+	// columns, or TableColumns in table format.
+	Columns      []string
+	TableColumns []string
+	// Sort orders items by a column before printing, descending when
+	// prefixed with "-". Sorting buffers the whole result.
+	Sort string
+	// Number maps a column to the fmt verb used for it in table output,
+	// e.g. "%.2f" for USDC amounts or "%+.2f%%" for percentages. Other
+	// formats keep full precision.
+	Number map[string]string
+}
+
+// Render writes v, a single value or a slice of values, in opts.Format.
+// A single value is printed as an object in JSON formats.
+func Render(w io.Writer, v any, opts Options) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		if opts.Format == JSON && len(opts.Columns) == 0 {
+			return writeIndented(w, v)
+		}
+
+		stream, err := NewStream(w, opts)
+		if err != nil {
+			return err
+		}
+		stream.single = true
+		if err := stream.Write(v); err != nil {
+			return err
+		}
+		return stream.Close()
+	}
+
+	stream, err := NewStream(w, opts)
+	if err != nil {
+		return err
+	}
+	stream.whole = true
+	for i := 0; i < rv.Len(); i++ {
+		if err := stream.Write(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return stream.Close()
+}
+
+func writeIndented(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+type item struct {
+	value  any
+	record record
+	raw    []byte
+}
+
+// Stream writes items one at a time. JSON, NDJSON and CSV items are written
+// as they arrive; tables and sorted output are written on Close. Columns
+// are taken from every item when the output is buffered and from the first
+// item otherwise.
+type Stream struct {
+	w       io.Writer
+	opts    Options
+	columns []string
+	count   int
+	single  bool
+	// whole is set by Render, which has every item up front and so can
+	// buffer them to pick columns from all of them.
+	whole   bool
+	pending []item
+	csv     *csv.Writer
+	tmpl    *template.Template
+	path    *jsonPath
+}
+
+func NewStream(w io.Writer, opts Options) (*Stream, error) {
+	if opts.Format == "" {
+		opts.Format = JSON
+	}
+	if !slices.Contains(Formats, opts.Format) {
+		return nil, fmt.Errorf("unknown output format %q", opts.Format)
+	}
+
+	s := &Stream{w: w, opts: opts}
+
+	var err error
+	switch opts.Format {
+	case Template:
+		if s.tmpl, err = template.New("output").Option("missingkey=zero").Parse(opts.Expression); err != nil {
+			return nil, fmt.Errorf("invalid output template: %w", err)
+		}
+	case JSONPath:
+		if s.path, err = parseJSONPath(opts.Expression); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// columnar reports whether the format prints columns, which --columns
+// selects; template and jsonpath pick their own fields.
+func (s *Stream) columnar() bool {
+	return s.opts.Format != Template && s.opts.Format != JSONPath
+}
+
+func (s *Stream) buffered() bool {
+	return s.whole || s.opts.Format == Table || s.opts.Sort != ""
+}
+
+func (s *Stream) Write(v any) error {
+	r, raw, err := newRecord(v)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	it := item{value: v, record: r, raw: raw}
+	if s.buffered() {
+		s.pending = append(s.pending, it)
+		return nil
+	}
+
+	if s.columns == nil && s.columnar() {
+		if s.columns, err = s.selectColumns(r); err != nil {
+			return err
+		}
+	}
+
+	return s.emit(it)
+}
+
+func (s *Stream) Close() error {
+	if s.buffered() {
+		if len(s.pending) > 0 {
+			all := record{values: make(map[string]any)}
+			for _, it := range s.pending {
+				all.merge(it.record)
+			}
+
+			if s.columnar() {
+				var err error
+				if s.columns, err = s.selectColumns(all); err != nil {
+					return err
+				}
+			}
+			if err := s.sortPending(all); err != nil {
+				return err
+			}
+		}
+
+		if s.opts.Format == Table {
+			return s.writeTable()
+		}
+		for _, it := range s.pending {
+			if err := s.emit(it); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch s.opts.Format {
+	case JSON:
+		if s.single && s.count == 1 {
+			return nil
+		}
+		if s.count == 0 {
+			_, err := fmt.Fprintln(s.w, "[]")
+			return err
+		}
+		_, err := fmt.Fprintln(s.w, "\n]")
+		return err
+	case CSV:
+		if s.csv == nil {
+			return nil
+		}
+		s.csv.Flush()
+		return s.csv.Error()
+	}
+
+	return nil
+}
+
+func (s *Stream) emit(it item) error {
+	defer func() { s.count++ }()
+
+	switch s.opts.Format {
+	case JSON:
+		data, err := s.jsonObject(it, true)
+		if err != nil {
+			return err
+		}
+		if s.single {
+			_, err = fmt.Fprintln(s.w, string(data))
+			return err
+		}
+		sep := ",\n  "
+		if s.count == 0 {
+			sep = "[\n  "
+		}
+		_, err = fmt.Fprint(s.w, sep+string(data))
+		return err
+	case NDJSON:
+		data, err := s.jsonObject(it, false)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(s.w, string(data))
+		return err
+	case CSV:
+		if s.csv == nil {
+			s.csv = csv.NewWriter(s.w)
+			if err := s.csv.Write(s.columns); err != nil {
+				return err
+			}
+		}
+		row := make([]string, len(s.columns))
+		for i, column := range s.columns {
+			row[i] = s.cell(column, it.record.values[column], false)
+		}
+		return s.csv.Write(row)
+	case Template:
+		var buf bytes.Buffer
+		if err := s.tmpl.Execute(&buf, it.value); err != nil {
+			return fmt.Errorf("failed to execute output template: %w", err)
+		}
+		return s.writeLine(buf.String())
+	case JSONPath:
+		line, err := s.path.execute(it.raw)
+		if err != nil {
+			return err
+		}
+		return s.writeLine(line)
+	}
+
+	return nil
+}
+
+// writeLine writes one template or jsonpath result, adding the newline
+// that ends each item unless the expression already printed one.
+func (s *Stream) writeLine(line string) error {
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+	_, err := io.WriteString(s.w, line)
+	return err
+}
+
+// jsonObject returns the item as JSON, projected onto the selected columns
+// when --columns is given.
+func (s *Stream) jsonObject(it item, indent bool) ([]byte, error) {
+	data := it.raw
+	if len(s.opts.Columns) > 0 {
+		var err error
+		if data, err = it.record.object(s.columns); err != nil {
+			return nil, fmt.Errorf("failed to format output: %w", err)
+		}
+	}
+
+	if !indent {
+		return data, nil
+	}
+
+	prefix := "  "
+	if s.single {
+		prefix = ""
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, prefix, "  "); err != nil {
+		return nil, fmt.Errorf("failed to format output: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *Stream) writeTable() error {
+	if s.columns == nil {
+		s.columns = s.opts.Columns
+		if len(s.columns) == 0 {
+			s.columns = s.opts.TableColumns
+		}
+	}
+	if len(s.columns) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(s.w, 0, 0, 2, ' ', 0)
+
+	header := make([]string, len(s.columns))
+	for i, column := range s.columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, it := range s.pending {
+		row := make([]string, len(s.columns))
+		for i, column := range s.columns {
+			row[i] = sanitizeCell(s.cell(column, it.record.values[column], true))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// selectColumns picks the columns of r to print. Columns named with
+// --columns must exist; default TableColumns that r lacks are skipped.
+func (s *Stream) selectColumns(r record) ([]string, error) {
+	if len(s.opts.Columns) > 0 {
+		columns := make([]string, 0, len(s.opts.Columns))
+		for _, column := range s.opts.Columns {
+			key, ok := r.lookup(column)
+			if !ok {
+				return nil, fmt.Errorf("unknown column %q (available: %s)", column, strings.Join(r.keys, ", "))
+			}
+			columns = append(columns, key)
+		}
+		return columns, nil
+	}
+
+	if s.opts.Format == Table {
+		var columns []string
+		for _, column := range s.opts.TableColumns {
+			if key, ok := r.lookup(column); ok {
+				columns = append(columns, key)
+			}
+		}
+		if len(columns) > 0 {
+			return columns, nil
+		}
+	}
+
+	return r.keys, nil
+}
+
+// sortPending orders the buffered items by the --sort column of all, the
+// merged record of every item.
+func (s *Stream) sortPending(all record) error {
+	if s.opts.Sort == "" {
+		return nil
+	}
+
+	column, desc := strings.CutPrefix(s.opts.Sort, "-")
+	key, ok := all.lookup(column)
+	if !ok {
+		return fmt.Errorf("unknown sort column %q (available: %s)", column, strings.Join(all.keys, ", "))
+	}
+
+	slices.SortStableFunc(s.pending, func(a, b item) int {
+		c := compare(a.record.values[key], b.record.values[key])
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	return nil
+}
+
+// cell formats a value for CSV or, with pretty, for a table where numbers
+// use the Number formats or are shortened to at most 4 decimals.
+func (s *Stream) cell(column string, value any, pretty bool) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case json.Number:
+		if !pretty {
+			return v.String()
+		}
+		if verb, ok := s.numberFormat(column); ok {
+			if f, err := v.Float64(); err == nil {
+				return fmt.Sprintf(verb, f)
+			}
+		}
+		return formatNumber(v)
+	case json.RawMessage:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (s *Stream) numberFormat(column string) (string, bool) {
+	for key, verb := range s.opts.Number {
+		if strings.EqualFold(key, column) {
+			return verb, true
+		}
+	}
+	return "", false
+}
+
+func formatNumber(n json.Number) string {
+	if _, err := n.Int64(); err == nil {
+		return n.String()
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return n.String()
+	}
+
+	formatted := strconv.FormatFloat(f, 'f', 4, 64)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+func sanitizeCell(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
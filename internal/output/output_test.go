@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type account struct {
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	Deployed *bool  `json:"deployed,omitempty"`
+	USDC     string `json:"usdc,omitempty"`
+}
+
+func accounts() []account {
+	deployed := true
+	return []account{
+		{Type: "EOA", Address: "0x1", USDC: "1"},
+		{Type: "SAFE", Address: "0x2", Deployed: &deployed, USDC: "2"},
+	}
+}
+
+func render(t *testing.T, v any, opts Options) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := Render(&buf, v, opts); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRenderColumnsFromAllItems(t *testing.T) {
+	got := render(t, accounts(), Options{Format: CSV})
+	want := "type,address,deployed,usdc\nEOA,0x1,,1\nSAFE,0x2,true,2\n"
+	if got != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = render(t, accounts(), Options{Format: CSV, Columns: []string{"type", "Deployed"}})
+	want = "type,deployed\nEOA,\nSAFE,true\n"
+	if got != want {
+		t.Errorf("csv with --columns:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = render(t, accounts(), Options{Format: Table, Sort: "-deployed"})
+	if lines := strings.Split(got, "\n"); !strings.HasPrefix(lines[0], "TYPE") || !strings.Contains(lines[0], "DEPLOYED") || !strings.HasPrefix(lines[1], "SAFE") {
+		t.Errorf("table sorted by -deployed:\n%s", got)
+	}
+}
+
+func TestRenderUnknownColumn(t *testing.T) {
+	var buf bytes.Buffer
+	err := Render(&buf, accounts(), Options{Format: CSV, Columns: []string{"missing"}})
+	if err == nil || !strings.Contains(err.Error(), `unknown column "missing"`) {
+		t.Errorf("err = %v, want unknown column", err)
+	}
+}
+
+func TestRenderSkipsMissingTableColumns(t *testing.T) {
+	got := render(t, accounts(), Options{Format: Table, TableColumns: []string{"type", "pol", "usdc"}})
+	want := "TYPE  USDC\nEOA   1\nSAFE  2\n"
+	if got != want {
+		t.Errorf("table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestStreamColumnsFromFirstItem(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := NewStream(&buf, Options{Format: CSV})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range accounts() {
+		if err := stream.Write(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "type,address,usdc\nEOA,0x1,1\nSAFE,0x2,2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("streamed csv:\n%s\nwant:\n%s", got, want)
+	}
+}
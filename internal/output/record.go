@@ -0,0 +1,181 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// record is an item flattened into ordered columns. Nested objects become
+// dotted keys ("market.title"); arrays are kept as compact JSON.
+type record struct {
+	keys   []string
+	values map[string]any
+}
+
+func newRecord(item any) (record, []byte, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return record{}, nil, err
+	}
+
+	r := record{values: make(map[string]any)}
+	if err := r.add("", data); err != nil {
+		return record{}, nil, err
+	}
+
+	return r, data, nil
+}
+
+func (r *record) add(key string, data json.RawMessage) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+
+	switch data[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			name := tok.(string)
+			if key != "" {
+				name = key + "." + name
+			}
+			if err := r.add(name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if key == "" {
+		key = "value"
+	}
+
+	var value any
+	switch data[0] {
+	case '[':
+		value = json.RawMessage(data)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+	}
+
+	r.keys = append(r.keys, key)
+	r.values[key] = value
+	return nil
+}
+
+// merge adds the keys of other that r lacks, each placed after the key it
+// follows in other, so that records missing optional fields still yield
+// every column in a stable order.
+func (r *record) merge(other record) {
+	pos := 0
+	for _, key := range other.keys {
+		if i := slices.Index(r.keys, key); i >= 0 {
+			pos = i + 1
+			continue
+		}
+		r.keys = slices.Insert(r.keys, pos, key)
+		r.values[key] = other.values[key]
+		pos++
+	}
+}
+
+// lookup finds key case-insensitively.
+func (r record) lookup(key string) (string, bool) {
+	if _, ok := r.values[key]; ok {
+		return key, true
+	}
+	for _, k := range r.keys {
+		if strings.EqualFold(k, key) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// object returns the selected columns as an ordered JSON object.
+func (r record) object(columns []string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		data, err := json.Marshal(r.values[column])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// compare orders two values numerically when both are numbers and as
+// strings otherwise. Missing values sort first.
+func compare(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	fa, aok := toFloat(a)
+	fb, bok := toFloat(b)
+	if aok && bok {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
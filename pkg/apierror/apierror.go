@@ -0,0 +1,148 @@
+// Package apierror describes non-2xx responses from the Polymarket APIs and
+// classifies them, so callers can tell rate limiting, bad credentials and
+// nonce conflicts apart from other failures.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBody caps how much of an error response is kept.
+const maxErrorBody = 64 << 10
+
+// requestIDHeaders are checked in order for the ID the server assigned to a
+// request, which is worth quoting when reporting a problem upstream.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amzn-Trace-Id", "Cf-Ray"}
+
+// Error is returned for any non-2xx response from the Polymarket APIs.
+type Error struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	// Message is the error reported in the JSON body ("error" or "message"),
+	// or the raw body when it is not JSON.
+	Message    string
+	Body       string
+	RequestID  string
+	RetryAfter time.Duration
+}
+
+// New builds an Error from resp, consuming its body.
+func New(resp *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	apiErr.RetryAfter = ParseRetryAfter(resp.Header.Get("Retry-After"))
+
+	var parsed struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		if parsed.Error != "" {
+			apiErr.Message = parsed.Error
+		} else if parsed.Message != "" {
+			apiErr.Message = parsed.Message
+		}
+	}
+
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("API returned status %d", e.StatusCode)
+	if e.Endpoint != "" {
+		msg += fmt.Sprintf(" for %s %s", e.Method, e.Endpoint)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Retryable reports whether the request may succeed if sent again.
+func (e *Error) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// ParseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. It returns zero when the header is absent or invalid.
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// As returns the Error in err's chain, if any.
+func As(err error) (*Error, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsRateLimited reports whether err is a 429 response.
+func IsRateLimited(err error) bool {
+	apiErr, ok := As(err)
+	return ok && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsAuthError reports whether err is a 401 or 403 response, e.g. bad builder
+// credentials or an invalid HMAC signature.
+func IsAuthError(err error) bool {
+	apiErr, ok := As(err)
+	return ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// IsNonceConflict reports whether the relayer rejected a request with 409
+// Conflict, which it returns when the nonce is stale or already used.
+func IsNonceConflict(err error) bool {
+	apiErr, ok := As(err)
+	return ok && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsRetryable reports whether err is an API error worth retrying.
+func IsRetryable(err error) bool {
+	apiErr, ok := As(err)
+	return ok && apiErr.Retryable()
+}
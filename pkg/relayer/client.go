@@ -2,21 +2,22 @@ package relayer
 
 import (
 	"bytes"
-	"crypto/ecdsa"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/big"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 
+	"polymarket-cli/pkg/apierror"
 	"polymarket-cli/pkg/relayer/transactions"
 )
 
@@ -28,6 +29,10 @@ const (
 var (
 	CTF_ADDRESS  = common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045")
 	USDC_ADDRESS = common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174")
+
+	EXCHANGE_ADDRESS          = common.HexToAddress("0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	NEG_RISK_EXCHANGE_ADDRESS = common.HexToAddress("0xC5d563A36AE78145C45a50134d48A1215220f80a")
+	NEG_RISK_ADAPTER_ADDRESS  = common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296")
 )
 
 type BuilderCreds struct {
@@ -39,42 +44,166 @@ type BuilderCreds struct {
 type Client struct {
 	baseURL    string
 	chainId    *big.Int
+	network    Network
 	httpClient *http.Client
+	logger     *slog.Logger
 	creds      *BuilderCreds
-	privateKey *ecdsa.PrivateKey
+	signer     Signer
 	address    common.Address
 	txType     RelayerTxType
+	nonce      *big.Int
+	relay      *common.Address
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// WithNetwork selects the relayer endpoint, chain and contracts. The default
+// is Polygon mainnet.
+func WithNetwork(network Network) Option {
+	return func(c *Client) {
+		c.network = network
+	}
+}
+
+// WithHTTPClient replaces the default HTTP client, which times out after 30s.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL overrides the relayer endpoint of the network.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
 }
 
-func NewClient(creds *BuilderCreds, txType RelayerTxType, owner *string, privateKeyHex *string) (*Client, error) {
+// WithChainID overrides the chain ID of the network.
+func WithChainID(chainID int64) Option {
+	return func(c *Client) {
+		c.chainId = big.NewInt(chainID)
+	}
+}
+
+// WithSigner sets the signer of relayer requests. Clients without a signer
+// can only submit pre-signed requests and query the relayer.
+func WithSigner(signer Signer) Option {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// WithTxType selects SAFE (the default) or PROXY transactions.
+func WithTxType(txType RelayerTxType) Option {
+	return func(c *Client) {
+		c.txType = txType
+	}
+}
+
+// WithCreds sets the builder API credentials used to authenticate submits.
+func WithCreds(creds *BuilderCreds) Option {
+	return func(c *Client) {
+		c.creds = creds
+	}
+}
+
+// WithLogger logs relayer requests at debug level. Logging is off by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// NewClient creates a relayer client for Polygon mainnet and SAFE
+// transactions unless configured otherwise by opts.
+func NewClient(opts ...Option) (*Client, error) {
 	client := &Client{
-		baseURL: DefaultRelayerURL,
-		chainId: big.NewInt(PolygonChainID),
+		network: Polygon,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		creds:  creds,
-		txType: txType,
+		logger: slog.New(slog.DiscardHandler),
+		txType: RelayerTxTypeSAFE,
 	}
 
-	if owner != nil {
-		client.address = common.HexToAddress(*owner)
+	for _, opt := range opts {
+		opt(client)
 	}
 
-	if privateKeyHex != nil {
-		privateKey, err := crypto.HexToECDSA(*privateKeyHex)
-		if err != nil {
-			return nil, fmt.Errorf("invalid private key: %w", err)
-		}
-		client.privateKey = privateKey
+	if client.txType == RelayerTxTypePROXY && !client.network.SupportsProxy() {
+		return nil, fmt.Errorf("%w: %s", ErrProxyUnsupported, client.network.Name)
+	}
 
-		publicKey := privateKey.PublicKey
-		client.address = crypto.PubkeyToAddress(publicKey)
+	if client.baseURL == "" {
+		client.baseURL = client.network.RelayerURL
+	}
+	if !strings.HasSuffix(client.baseURL, "/") {
+		client.baseURL += "/"
+	}
+
+	if client.chainId == nil {
+		client.chainId = big.NewInt(client.network.ChainID)
+	}
+
+	if client.signer != nil {
+		client.address = client.signer.Address()
 	}
 
 	return client, nil
 }
 
+// do sends req and logs it along with the response status.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Debug("relayer request failed", "method", req.Method, "url", req.URL.String(), "error", err)
+		return nil, err
+	}
+
+	c.logger.Debug("relayer request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", time.Since(start))
+	return resp, nil
+}
+
+// Address returns the signer (owner) address.
+func (c *Client) Address() common.Address {
+	return c.address
+}
+
+// WalletAddress returns the Safe or proxy wallet the relayer acts on for the
+// configured transaction type.
+func (c *Client) WalletAddress() common.Address {
+	return c.network.DeriveWallet(c.address, c.txType)
+}
+
+// Close releases the signer when it implements io.Closer, as ExternalSigner
+// does.
+func (c *Client) Close() error {
+	if closer, ok := c.signer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Network returns the network the client is configured for.
+func (c *Client) Network() Network {
+	return c.network
+}
+
+// SetNonce makes the client sign with the given nonce instead of fetching
+// it from the relayer, so requests can be built and signed offline.
+func (c *Client) SetNonce(nonce *big.Int) {
+	c.nonce = nonce
+}
+
+// SetRelay sets the relay address signed into PROXY requests instead of
+// fetching it from the relayer's relay payload.
+func (c *Client) SetRelay(relay common.Address) {
+	c.relay = &relay
+}
+
 type RelayerTxType string
 
 const (
@@ -89,23 +218,81 @@ type ExecuteResponse struct {
 	TransactionHash string `json:"transactionHash"`
 }
 
+// PreparedTransaction is a signed relayer request along with the calls it
+// executes and the hash that was signed.
+type PreparedTransaction struct {
+	Request      *transactions.TransactionRequest
+	StructHash   []byte
+	Transactions []*transactions.Transaction
+}
+
 type NonceResponse struct {
 	Nonce string `json:"nonce"`
 }
 
+type RelayPayload struct {
+	Address string `json:"address"`
+	Nonce   string `json:"nonce"`
+}
+
 func (c *Client) Execute(txs []*transactions.Transaction, metadata string) (*ExecuteResponse, error) {
-	request, err := c.buildTransactionRequest(txs, metadata)
+	return c.ExecuteContext(context.Background(), txs, metadata)
+}
+
+// ExecuteContext builds, signs and submits txs. For SAFE transactions it
+// fails with SafeNotDeployedError when the Safe does not exist yet.
+func (c *Client) ExecuteContext(ctx context.Context, txs []*transactions.Transaction, metadata string) (*ExecuteResponse, error) {
+	if c.txType == RelayerTxTypeSAFE {
+		safeAddress := c.WalletAddress()
+		deployed, err := c.GetDeployedContext(ctx, safeAddress.Hex())
+		if err != nil {
+			return nil, err
+		}
+		if !deployed {
+			return nil, &SafeNotDeployedError{Address: safeAddress}
+		}
+	}
+
+	prepared, err := c.BuildContext(ctx, txs, metadata)
 	if err != nil {
 		return nil, err
 	}
 
+	return c.SubmitContext(ctx, prepared.Request)
+}
+
+// Build signs a relayer request for txs without submitting it.
+func (c *Client) Build(txs []*transactions.Transaction, metadata string) (*PreparedTransaction, error) {
+	return c.BuildContext(context.Background(), txs, metadata)
+}
+
+// BuildContext is Build with a context for the nonce lookup.
+func (c *Client) BuildContext(ctx context.Context, txs []*transactions.Transaction, metadata string) (*PreparedTransaction, error) {
+	request, structHash, err := c.buildTransactionRequest(ctx, txs, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreparedTransaction{
+		Request:      request,
+		StructHash:   structHash,
+		Transactions: txs,
+	}, nil
+}
+
+// Submit posts a signed request to the relayer.
+func (c *Client) Submit(request *transactions.TransactionRequest) (*ExecuteResponse, error) {
+	return c.SubmitContext(context.Background(), request)
+}
+
+func (c *Client) SubmitContext(ctx context.Context, request *transactions.TransactionRequest) (*ExecuteResponse, error) {
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 	bodyStr := string(bodyBytes)
 
-	req, err := http.NewRequest("POST", c.baseURL+"submit", bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"submit", bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -126,15 +313,14 @@ func (c *Client) Execute(txs []*transactions.Transaction, metadata string) (*Exe
 		req.Header.Set("POLY_BUILDER_SIGNATURE", signature)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, apierror.New(resp)
 	}
 
 	var result ExecuteResponse
@@ -146,7 +332,11 @@ func (c *Client) Execute(txs []*transactions.Transaction, metadata string) (*Exe
 }
 
 func (c *Client) GetNonce(signerAddress string) (*string, error) {
-	req, err := http.NewRequest("GET", c.baseURL+"nonce", nil)
+	return c.GetNonceContext(context.Background(), signerAddress)
+}
+
+func (c *Client) GetNonceContext(ctx context.Context, signerAddress string) (*string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"nonce", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -156,13 +346,14 @@ func (c *Client) GetNonce(signerAddress string) (*string, error) {
 	q.Add("type", string(c.txType))
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get request: %w", err)
 	}
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, apierror.New(resp)
 	}
 
 	var result NonceResponse
@@ -173,7 +364,40 @@ func (c *Client) GetNonce(signerAddress string) (*string, error) {
 	return &result.Nonce, nil
 }
 
-func (c *Client) buildTransactionRequest(txs []*transactions.Transaction, metadata string) (*transactions.TransactionRequest, error) {
+func (c *Client) GetRelayPayload(signerAddress string) (*RelayPayload, error) {
+	return c.GetRelayPayloadContext(context.Background(), signerAddress)
+}
+
+func (c *Client) GetRelayPayloadContext(ctx context.Context, signerAddress string) (*RelayPayload, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"relay-payload", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("address", signerAddress)
+	q.Add("type", string(c.txType))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apierror.New(resp)
+	}
+
+	var result RelayPayload
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (c *Client) buildTransactionRequest(ctx context.Context, txs []*transactions.Transaction, metadata string) (*transactions.TransactionRequest, []byte, error) {
 	switch c.txType {
 	case RelayerTxTypeSAFE:
 		stxs := make([]*transactions.SafeTransaction, len(txs))
@@ -185,18 +409,36 @@ func (c *Client) buildTransactionRequest(txs []*transactions.Transaction, metada
 				Value:     tx.Value,
 			}
 		}
-		return c.buildSafeTransactionRequest(stxs, metadata)
+		return c.buildSafeTransactionRequest(ctx, stxs, metadata)
+	case RelayerTxTypePROXY:
+		ptxs := make([]*transactions.ProxyTransaction, len(txs))
+		for i, tx := range txs {
+			ptxs[i] = &transactions.ProxyTransaction{
+				TypeCode: transactions.ProxyCallTypeCall,
+				To:       tx.To,
+				Value:    tx.Value,
+				Data:     tx.Data,
+			}
+		}
+		return c.buildProxyTransactionRequest(ctx, ptxs, metadata)
 	default:
-		return nil, errors.New("unsupport type")
+		return nil, nil, errors.New("unsupport type")
 	}
 }
 
 func (c *Client) BuildSafeStructHash(safeAddress common.Address, tx *transactions.SafeTransaction) ([]byte, *big.Int, error) {
-	nonce, err := c.GetNonce(c.address.Hex())
-	if err != nil {
-		return nil, nil, err
+	return c.BuildSafeStructHashContext(context.Background(), safeAddress, tx)
+}
+
+func (c *Client) BuildSafeStructHashContext(ctx context.Context, safeAddress common.Address, tx *transactions.SafeTransaction) ([]byte, *big.Int, error) {
+	nonceBig := c.nonce
+	if nonceBig == nil {
+		nonce, err := c.GetNonceContext(ctx, c.address.Hex())
+		if err != nil {
+			return nil, nil, err
+		}
+		nonceBig, _ = new(big.Int).SetString(*nonce, 10)
 	}
-	nonceBig, _ := new(big.Int).SetString(*nonce, 10)
 
 	gasToken := ZeroAddress
 	refundReceiver := ZeroAddress
@@ -218,20 +460,21 @@ func (c *Client) BuildSafeStructHash(safeAddress common.Address, tx *transaction
 	return hash, nonceBig, err
 }
 
-func (c *Client) buildSafeTransactionRequest(txs []*transactions.SafeTransaction, metadata string) (*transactions.TransactionRequest, error) {
-	safeMultisend := common.HexToAddress(SafeMultisend)
-	transaction, err := aggregateTransaction(txs, safeMultisend)
-	safeFactory := common.HexToAddress(SafeFactory)
-	safeAddress := DeriveSafe(c.address, safeFactory).Hex()
+func (c *Client) buildSafeTransactionRequest(ctx context.Context, txs []*transactions.SafeTransaction, metadata string) (*transactions.TransactionRequest, []byte, error) {
+	transaction, err := aggregateTransaction(txs, c.network.SafeMultisend)
+	if err != nil {
+		return nil, nil, err
+	}
+	safeAddress := c.network.DeriveSafe(c.address).Hex()
 
-	structHash, nonce, err := c.BuildSafeStructHash(common.HexToAddress(safeAddress), transaction)
+	structHash, nonce, err := c.BuildSafeStructHashContext(ctx, common.HexToAddress(safeAddress), transaction)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	signature, err := c.SignMessage(structHash)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	nonceStr := nonce.String()
@@ -255,13 +498,108 @@ func (c *Client) buildSafeTransactionRequest(txs []*transactions.SafeTransaction
 		},
 		Type:     "SAFE",
 		Metadata: &metadata,
-	}, nil
+	}, structHash, nil
 }
 
-func (c *Client) SignMessage(message []byte) (string, error) {
-	hash := accounts.TextHash(message)
+func (c *Client) buildProxyTransactionRequest(ctx context.Context, txs []*transactions.ProxyTransaction, metadata string) (*transactions.TransactionRequest, []byte, error) {
+	proxyFactory := c.network.ProxyFactory
+	relayHub := c.network.RelayHub
+	proxyWallet := c.network.DeriveProxyWallet(c.address).Hex()
+
+	data, err := encodeProxyTransactionData(txs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce, relay, err := c.relayPayload(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasPrice := big.NewInt(0)
+	relayerFee := big.NewInt(0)
+	gasLimit := big.NewInt(DefaultProxyGasLimit)
+
+	structHash := CreateProxyStructHash(
+		c.address,
+		proxyFactory,
+		data,
+		relayerFee,
+		gasPrice,
+		gasLimit,
+		nonce,
+		relayHub,
+		relay,
+	)
+
+	sig, err := c.signEthMessage(structHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature := "0x" + hex.EncodeToString(sig)
+
+	nonceStr := nonce.String()
+	gasPriceStr := gasPrice.String()
+	gasLimitStr := gasLimit.String()
+	relayerFeeStr := relayerFee.String()
+	relayHubStr := relayHub.Hex()
+	relayStr := relay.Hex()
+	return &transactions.TransactionRequest{
+		From:        c.address.Hex(),
+		To:          proxyFactory.Hex(),
+		ProxyWallet: &proxyWallet,
+		Data:        "0x" + hex.EncodeToString(data),
+		Nonce:       &nonceStr,
+		Signature:   signature,
+		SignatureParams: transactions.SignatureParams{
+			GasPrice:   &gasPriceStr,
+			GasLimit:   &gasLimitStr,
+			RelayerFee: &relayerFeeStr,
+			RelayHub:   &relayHubStr,
+			Relay:      &relayStr,
+		},
+		Type:     "PROXY",
+		Metadata: &metadata,
+	}, structHash, nil
+}
+
+// relayPayload returns the nonce and relay address to sign into a PROXY
+// request, preferring values set with SetNonce and SetRelay.
+func (c *Client) relayPayload(ctx context.Context) (*big.Int, common.Address, error) {
+	if c.nonce != nil {
+		if c.relay == nil {
+			return nil, common.Address{}, errors.New("relay address is required to sign PROXY requests offline")
+		}
+		return c.nonce, *c.relay, nil
+	}
+
+	payload, err := c.GetRelayPayloadContext(ctx, c.address.Hex())
+	if err != nil {
+		return nil, common.Address{}, err
+	}
 
-	sig, err := crypto.Sign(hash, c.privateKey)
+	nonce, ok := new(big.Int).SetString(payload.Nonce, 10)
+	if !ok {
+		return nil, common.Address{}, fmt.Errorf("invalid relay payload nonce: %q", payload.Nonce)
+	}
+
+	return nonce, common.HexToAddress(payload.Address), nil
+}
+
+var ErrNoSigner = errors.New("relayer client has no signer")
+
+// signEthMessage signs message with the EIP-191 personal message prefix and
+// returns the signature with v in {27, 28}.
+func (c *Client) signEthMessage(message []byte) ([]byte, error) {
+	if c.signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	return c.signer.SignHash(message)
+}
+
+func (c *Client) SignMessage(message []byte) (string, error) {
+	sig, err := c.signEthMessage(message)
 	if err != nil {
 		return "", err
 	}
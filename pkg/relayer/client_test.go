@@ -0,0 +1,119 @@
+package relayer
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+// testKey is the well-known example key from the web3.js documentation,
+// address 0x2c7536E3605D9C16a7a3D7b1898e529396a65c23.
+const testKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestBuildProxyRequest(t *testing.T) {
+	signer, err := NewHexKeySigner(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClient(WithSigner(signer), WithTxType(RelayerTxTypePROXY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetNonce(big.NewInt(3))
+	client.SetRelay(common.HexToAddress("0x0000000000000000000000000000000000000001"))
+
+	prepared, err := client.Build([]*transactions.Transaction{{
+		To:    Polygon.CTF,
+		Data:  []byte{0xde, 0xad, 0xbe, 0xef},
+		Value: big.NewInt(0),
+	}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := prepared.Request
+	if request.To != Polygon.ProxyFactory.Hex() {
+		t.Errorf("to = %s, want the proxy factory %s", request.To, Polygon.ProxyFactory.Hex())
+	}
+	if *request.ProxyWallet != Polygon.DeriveProxyWallet(signer.Address()).Hex() {
+		t.Errorf("proxy wallet = %s, want %s", *request.ProxyWallet, Polygon.DeriveProxyWallet(signer.Address()).Hex())
+	}
+	if *request.SignatureParams.GasLimit != "10000000" || *request.SignatureParams.Relay != "0x0000000000000000000000000000000000000001" || *request.Nonce != "3" {
+		t.Errorf("signature params = %+v, nonce %s", request.SignatureParams, *request.Nonce)
+	}
+
+	const goldenHash = "de087e64a95ff0ef8c263803b5e3f9c632579e46d74c9eb342bb5310895010e5"
+	if got := hex.EncodeToString(prepared.StructHash); got != goldenHash {
+		t.Errorf("struct hash = %s, want %s", got, goldenHash)
+	}
+
+	// The owner signs the struct hash as an EIP-191 personal message, with
+	// v in {27, 28}.
+	const goldenSignature = "0x4cb5b7588b834d53610e5f84aee8ead9b135dcc62f70a253b14c71a9a1cfc09d145ce1d92d56f74fe2aaa45f2370f193061692deb10cd853baaf45462dbd41031b"
+	if request.Signature != goldenSignature {
+		t.Errorf("signature = %s, want %s", request.Signature, goldenSignature)
+	}
+
+	sig, _ := hex.DecodeString(request.Signature[2:])
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(prepared.StructHash), sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != signer.Address() {
+		t.Errorf("signature recovers to %s, want %s", got.Hex(), signer.Address().Hex())
+	}
+}
+
+func TestBuildSafeCreate(t *testing.T) {
+	signer, err := NewHexKeySigner(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClient(WithSigner(signer), WithTxType(RelayerTxTypeSAFE))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prepared, err := client.BuildSafeCreate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const goldenHash = "563ac315294c5be01ab1f3b04a5abdfa39e8317a9d90679d4e63caf760b126a4"
+	if got := hex.EncodeToString(prepared.StructHash); got != goldenHash {
+		t.Errorf("digest = %s, want %s", got, goldenHash)
+	}
+
+	request := prepared.Request
+	if request.To != Polygon.SafeFactory.Hex() {
+		t.Errorf("to = %s, want the safe factory %s", request.To, Polygon.SafeFactory.Hex())
+	}
+	if *request.ProxyWallet != Polygon.DeriveSafe(signer.Address()).Hex() {
+		t.Errorf("safe = %s, want %s", *request.ProxyWallet, Polygon.DeriveSafe(signer.Address()).Hex())
+	}
+
+	// The digest is signed directly (no personal message prefix).
+	const goldenSignature = "0xd0efafcc4ca4b56b9e0bc406332dc894ca3b005ba1e47ec971d38c30c6b85c17543fde65dcab82376f67ecf580efe8dfd7db04ea4ef9af0b9fa114aeed2d56ea1b"
+	if request.Signature != goldenSignature {
+		t.Errorf("signature = %s, want %s", request.Signature, goldenSignature)
+	}
+
+	sig, _ := hex.DecodeString(request.Signature[2:])
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(prepared.StructHash, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != signer.Address() {
+		t.Errorf("signature recovers to %s, want %s", got.Hex(), signer.Address().Hex())
+	}
+}
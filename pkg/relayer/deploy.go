@@ -0,0 +1,204 @@
+package relayer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"polymarket-cli/pkg/apierror"
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+const SafeFactoryName = "Polymarket Contract Proxy Factory"
+
+var ErrSafeAlreadyDeployed = errors.New("safe is already deployed")
+
+// SafeNotDeployedError is returned by Execute when the signer's Safe has not
+// been deployed yet. Deploy it with DeploySafe first.
+type SafeNotDeployedError struct {
+	Address common.Address
+}
+
+func (e *SafeNotDeployedError) Error() string {
+	return fmt.Sprintf("safe %s is not deployed; deploy it first (polymarket-cli wallet deploy)", e.Address.Hex())
+}
+
+type DeployedResponse struct {
+	Deployed bool `json:"deployed"`
+}
+
+func (c *Client) GetDeployed(safeAddress string) (bool, error) {
+	return c.GetDeployedContext(context.Background(), safeAddress)
+}
+
+func (c *Client) GetDeployedContext(ctx context.Context, safeAddress string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"deployed", nil)
+	if err != nil {
+		return false, err
+	}
+
+	q := req.URL.Query()
+	q.Add("address", safeAddress)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to get request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, apierror.New(resp)
+	}
+
+	var result DeployedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Deployed, nil
+}
+
+// DeploySafe submits a gasless SAFE-CREATE request for the signer's Safe.
+// It returns ErrSafeAlreadyDeployed when there is nothing to do.
+func (c *Client) DeploySafe() (*ExecuteResponse, error) {
+	return c.DeploySafeContext(context.Background())
+}
+
+func (c *Client) DeploySafeContext(ctx context.Context) (*ExecuteResponse, error) {
+	deployed, err := c.GetDeployedContext(ctx, c.WalletAddress().Hex())
+	if err != nil {
+		return nil, err
+	}
+	if deployed {
+		return nil, ErrSafeAlreadyDeployed
+	}
+
+	prepared, err := c.BuildSafeCreate()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SubmitContext(ctx, prepared.Request)
+}
+
+// BuildSafeCreate signs a SAFE-CREATE request for the signer's Safe without
+// checking whether it is deployed or submitting it.
+func (c *Client) BuildSafeCreate() (*PreparedTransaction, error) {
+	if c.txType != RelayerTxTypeSAFE {
+		return nil, fmt.Errorf("safe deployment requires the SAFE transaction type, got %s", c.txType)
+	}
+
+	safeFactory := c.network.SafeFactory
+	safeAddress := c.network.DeriveSafe(c.address)
+
+	request, structHash, err := c.buildSafeCreateTransactionRequest(safeFactory, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreparedTransaction{
+		Request:    request,
+		StructHash: structHash,
+	}, nil
+}
+
+func (c *Client) buildSafeCreateTransactionRequest(safeFactory, safeAddress common.Address) (*transactions.TransactionRequest, []byte, error) {
+	paymentToken := common.HexToAddress(ZeroAddress)
+	payment := big.NewInt(0)
+	paymentReceiver := common.HexToAddress(ZeroAddress)
+
+	if c.signer == nil {
+		return nil, nil, ErrNoSigner
+	}
+
+	typedData := safeCreateTypedData(c.chainId, safeFactory, paymentToken, payment, paymentReceiver)
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sig, err := c.signer.SignTypedData(typedData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	safeAddressStr := safeAddress.Hex()
+	paymentTokenStr := paymentToken.Hex()
+	paymentStr := payment.String()
+	paymentReceiverStr := paymentReceiver.Hex()
+	return &transactions.TransactionRequest{
+		From:        c.address.Hex(),
+		To:          safeFactory.Hex(),
+		ProxyWallet: &safeAddressStr,
+		Data:        "0x",
+		Signature:   "0x" + hex.EncodeToString(sig),
+		SignatureParams: transactions.SignatureParams{
+			PaymentToken:    &paymentTokenStr,
+			Payment:         &paymentStr,
+			PaymentReceiver: &paymentReceiverStr,
+		},
+		Type: "SAFE-CREATE",
+	}, hash, nil
+}
+
+// CreateSafeCreateHash returns the EIP-712 digest of the CreateProxy message
+// the Safe factory expects from the owner.
+func CreateSafeCreateHash(
+	chainId *big.Int,
+	safeFactory common.Address,
+	paymentToken common.Address,
+	payment *big.Int,
+	paymentReceiver common.Address,
+) ([]byte, error) {
+	typedData := safeCreateTypedData(chainId, safeFactory, paymentToken, payment, paymentReceiver)
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return hash, nil
+}
+
+func safeCreateTypedData(
+	chainId *big.Int,
+	safeFactory common.Address,
+	paymentToken common.Address,
+	payment *big.Int,
+	paymentReceiver common.Address,
+) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"CreateProxy": []apitypes.Type{
+				{Name: "paymentToken", Type: "address"},
+				{Name: "payment", Type: "uint256"},
+				{Name: "paymentReceiver", Type: "address"},
+			},
+		},
+		PrimaryType: "CreateProxy",
+		Domain: apitypes.TypedDataDomain{
+			Name:              SafeFactoryName,
+			ChainId:           math.NewHexOrDecimal256(chainId.Int64()),
+			VerifyingContract: safeFactory.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"paymentToken":    paymentToken.Hex(),
+			"payment":         payment.String(),
+			"paymentReceiver": paymentReceiver.Hex(),
+		},
+	}
+}
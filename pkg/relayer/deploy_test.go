@@ -0,0 +1,84 @@
+package relayer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// eip712Digest hashes a CreateProxy message by hand, independently of the
+// apitypes encoder CreateSafeCreateHash uses.
+func eip712Digest(chainID int64, factory, paymentToken common.Address, payment *big.Int, paymentReceiver common.Address) []byte {
+	domainType := crypto.Keccak256([]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)"))
+	domain := crypto.Keccak256(
+		domainType,
+		crypto.Keccak256([]byte(SafeFactoryName)),
+		math.U256Bytes(big.NewInt(chainID)),
+		common.LeftPadBytes(factory.Bytes(), 32),
+	)
+
+	messageType := crypto.Keccak256([]byte("CreateProxy(address paymentToken,uint256 payment,address paymentReceiver)"))
+	message := crypto.Keccak256(
+		messageType,
+		common.LeftPadBytes(paymentToken.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(payment)),
+		common.LeftPadBytes(paymentReceiver.Bytes(), 32),
+	)
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, domain, message)
+}
+
+func TestCreateSafeCreateHash(t *testing.T) {
+	tests := []struct {
+		name            string
+		chainID         int64
+		paymentToken    common.Address
+		payment         int64
+		paymentReceiver common.Address
+		golden          string
+	}{
+		{
+			name:    "polygon",
+			chainID: 137,
+			golden:  "563ac315294c5be01ab1f3b04a5abdfa39e8317a9d90679d4e63caf760b126a4",
+		},
+		{
+			name:    "amoy",
+			chainID: 80002,
+			golden:  "2d129dff04eda1c1378e6a7545207c971c6b04e99640d0870119500a870ddb97",
+		},
+		{
+			name:            "polygon with payment",
+			chainID:         137,
+			paymentToken:    common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"),
+			payment:         1_000_000,
+			paymentReceiver: common.HexToAddress("0x0000000000000000000000000000000000000001"),
+			golden:          "0a7a77510d591b98bf43d9f1f2983ba4ad184b080a43ec10738a0f24c1af0bd5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := common.HexToAddress(SafeFactory)
+
+			hash, err := CreateSafeCreateHash(big.NewInt(tt.chainID), factory, tt.paymentToken, big.NewInt(tt.payment), tt.paymentReceiver)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hex.EncodeToString(hash); got != tt.golden {
+				t.Errorf("digest = %s, want %s", got, tt.golden)
+			}
+
+			want := eip712Digest(tt.chainID, factory, tt.paymentToken, big.NewInt(tt.payment), tt.paymentReceiver)
+			if !bytes.Equal(hash, want) {
+				t.Errorf("digest = %x, want hand-encoded EIP-712 digest %x", hash, want)
+			}
+		})
+	}
+}
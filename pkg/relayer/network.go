@@ -0,0 +1,105 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const AmoyChainID = 80002
+
+var ErrProxyUnsupported = errors.New("proxy wallets are not supported on this network")
+
+// Network holds the relayer endpoint, chain and contract addresses the
+// client works against. ProxyFactory and RelayHub are zero on networks
+// without proxy wallet support.
+type Network struct {
+	Name       string
+	ChainID    int64
+	RelayerURL string
+
+	CTF             common.Address
+	USDC            common.Address
+	Exchange        common.Address
+	NegRiskExchange common.Address
+	NegRiskAdapter  common.Address
+
+	SafeFactory       common.Address
+	SafeMultisend     common.Address
+	SafeInitCodeHash  common.Hash
+	ProxyFactory      common.Address
+	RelayHub          common.Address
+	ProxyInitCodeHash common.Hash
+}
+
+var Polygon = Network{
+	Name:       "polygon",
+	ChainID:    PolygonChainID,
+	RelayerURL: DefaultRelayerURL,
+
+	CTF:             CTF_ADDRESS,
+	USDC:            USDC_ADDRESS,
+	Exchange:        EXCHANGE_ADDRESS,
+	NegRiskExchange: NEG_RISK_EXCHANGE_ADDRESS,
+	NegRiskAdapter:  NEG_RISK_ADAPTER_ADDRESS,
+
+	SafeFactory:       common.HexToAddress(SafeFactory),
+	SafeMultisend:     common.HexToAddress(SafeMultisend),
+	SafeInitCodeHash:  common.HexToHash(SAFEInitCodeHash),
+	ProxyFactory:      common.HexToAddress(ProxyFactory),
+	RelayHub:          common.HexToAddress(RelayHub),
+	ProxyInitCodeHash: common.HexToHash(PROXYInitCodeHashHex),
+}
+
+var Amoy = Network{
+	Name:       "amoy",
+	ChainID:    AmoyChainID,
+	RelayerURL: "https://relayer-v2-staging.polymarket.dev/",
+
+	CTF:             common.HexToAddress("0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB"),
+	USDC:            common.HexToAddress("0x9c4e1703476e875070ee25b56a58b008cfb8fa78"),
+	Exchange:        common.HexToAddress("0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"),
+	NegRiskExchange: common.HexToAddress("0xC5d563A36AE78145C45a50134d48A1215220f80a"),
+	NegRiskAdapter:  common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"),
+
+	SafeFactory:      common.HexToAddress(SafeFactory),
+	SafeMultisend:    common.HexToAddress(SafeMultisend),
+	SafeInitCodeHash: common.HexToHash(SAFEInitCodeHash),
+}
+
+// NetworkByName returns the preset with the given name.
+func NetworkByName(name string) (Network, error) {
+	switch strings.ToLower(name) {
+	case "", Polygon.Name, "matic", "mainnet":
+		return Polygon, nil
+	case Amoy.Name:
+		return Amoy, nil
+	default:
+		return Network{}, fmt.Errorf("unknown network %q (expected polygon or amoy)", name)
+	}
+}
+
+// SupportsProxy reports whether PROXY transactions can be relayed.
+func (n Network) SupportsProxy() bool {
+	return n.ProxyFactory != (common.Address{})
+}
+
+// DeriveSafe returns the Safe of owner on this network.
+func (n Network) DeriveSafe(owner common.Address) common.Address {
+	return deriveSafe(owner, n.SafeFactory, n.SafeInitCodeHash)
+}
+
+// DeriveProxyWallet returns the proxy wallet of owner on this network.
+func (n Network) DeriveProxyWallet(owner common.Address) common.Address {
+	return deriveProxyWallet(owner, n.ProxyFactory, n.ProxyInitCodeHash)
+}
+
+// DeriveWallet returns the Safe or proxy wallet of owner for txType.
+func (n Network) DeriveWallet(owner common.Address, txType RelayerTxType) common.Address {
+	if txType == RelayerTxTypePROXY {
+		return n.DeriveProxyWallet(owner)
+	}
+	return n.DeriveSafe(owner)
+}
@@ -0,0 +1,93 @@
+package relayer
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+const (
+	ProxyFactory         = "0xaB45c5A4B0c941a2F231C04C3f49182e1A254052"
+	RelayHub             = "0xD216153c06E857cD7f72665E0aF1d7D82172F494"
+	DefaultProxyGasLimit = 10_000_000
+)
+
+func encodeProxyTransactionData(txs []*transactions.ProxyTransaction) ([]byte, error) {
+	proxyABI := `
+	[{
+      "inputs": [
+        {
+          "components": [
+            { "internalType": "enum ProxyWalletLib.CallType", "name": "typeCode", "type": "uint8" },
+            { "internalType": "address", "name": "to", "type": "address" },
+            { "internalType": "uint256", "name": "value", "type": "uint256" },
+            { "internalType": "bytes", "name": "data", "type": "bytes" }
+          ],
+          "internalType": "struct ProxyWalletLib.ProxyCall[]",
+          "name": "calls",
+          "type": "tuple[]"
+        }
+      ],
+      "name": "proxy",
+      "outputs": [
+        { "internalType": "bytes[]", "name": "returnValues", "type": "bytes[]" }
+      ],
+      "stateMutability": "payable",
+      "type": "function"
+    }]
+	`
+
+	parsedABI, err := abi.JSON(strings.NewReader(proxyABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	calls := make([]transactions.ProxyTransaction, len(txs))
+	for i, tx := range txs {
+		calls[i] = *tx
+	}
+
+	data, err := parsedABI.Pack("proxy", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
+
+// CreateProxyStructHash returns the relay hub hash the proxy wallet owner
+// signs: keccak256("rlx:" ++ from ++ to ++ data ++ txFee ++ gasPrice ++
+// gasLimit ++ nonce ++ relayHub ++ relay).
+func CreateProxyStructHash(
+	from common.Address,
+	to common.Address,
+	data []byte,
+	txFee *big.Int,
+	gasPrice *big.Int,
+	gasLimit *big.Int,
+	nonce *big.Int,
+	relayHub common.Address,
+	relay common.Address,
+) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("rlx:")
+	buf.Write(from.Bytes())
+	buf.Write(to.Bytes())
+	buf.Write(data)
+	buf.Write(uint256ToBytes(txFee))
+	buf.Write(uint256ToBytes(gasPrice))
+	buf.Write(uint256ToBytes(gasLimit))
+	buf.Write(uint256ToBytes(nonce))
+	buf.Write(relayHub.Bytes())
+	buf.Write(relay.Bytes())
+
+	return crypto.Keccak256(buf.Bytes())
+}
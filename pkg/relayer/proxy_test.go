@@ -0,0 +1,99 @@
+package relayer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"polymarket-cli/pkg/relayer/transactions"
+)
+
+// word left-pads hex digits to a 32-byte ABI word.
+func word(digits string) string {
+	return strings.Repeat("0", 64-len(digits)) + digits
+}
+
+// hexAddress returns the lowercase hex of an address without 0x.
+func hexAddress(a common.Address) string {
+	return hex.EncodeToString(a.Bytes())
+}
+
+func testProxyCall() []*transactions.ProxyTransaction {
+	return []*transactions.ProxyTransaction{{
+		TypeCode: 1,
+		To:       CTF_ADDRESS,
+		Value:    big.NewInt(0),
+		Data:     []byte{0xde, 0xad, 0xbe, 0xef},
+	}}
+}
+
+func TestEncodeProxyTransactionData(t *testing.T) {
+	data, err := encodeProxyTransactionData(testProxyCall())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "34ee9791" + // proxy((uint8,address,uint256,bytes)[])
+		word("20") + // offset of calls
+		word("1") + // calls.length
+		word("20") + // offset of calls[0]
+		word("1") + // typeCode: CALL
+		word(hexAddress(CTF_ADDRESS)) +
+		word("0") + // value
+		word("80") + // offset of data within the tuple
+		word("4") + // data.length
+		"deadbeef" + strings.Repeat("0", 56)
+
+	if got := hex.EncodeToString(data); got != want {
+		t.Errorf("encoded proxy call\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestCreateProxyStructHash(t *testing.T) {
+	from := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	relay := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	data, err := encodeProxyTransactionData(testProxyCall())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := CreateProxyStructHash(
+		from,
+		common.HexToAddress(ProxyFactory),
+		data,
+		big.NewInt(0),
+		big.NewInt(0),
+		big.NewInt(DefaultProxyGasLimit),
+		big.NewInt(3),
+		common.HexToAddress(RelayHub),
+		relay,
+	)
+
+	const golden = "de087e64a95ff0ef8c263803b5e3f9c632579e46d74c9eb342bb5310895010e5"
+	if got := hex.EncodeToString(hash); got != golden {
+		t.Errorf("struct hash = %s, want %s", got, golden)
+	}
+
+	// The relay hub hashes the packed fields: "rlx:", from, to, data, then
+	// fee, gas price, gas limit and nonce as uint256, then hub and relay.
+	packed, err := hex.DecodeString(
+		hex.EncodeToString([]byte("rlx:")) +
+			hexAddress(from) +
+			hexAddress(common.HexToAddress(ProxyFactory)) +
+			hex.EncodeToString(data) +
+			word("0") + word("0") + word("989680") + word("3") +
+			hexAddress(common.HexToAddress(RelayHub)) +
+			hexAddress(relay))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crypto.Keccak256(packed); !bytes.Equal(hash, want) {
+		t.Errorf("struct hash = %x, want keccak of packed fields %x", hash, want)
+	}
+}
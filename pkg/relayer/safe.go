@@ -24,11 +24,19 @@ const (
 )
 
 func DeriveProxyWallet(address, proxyFactory common.Address) common.Address {
-	salt := crypto.Keccak256Hash(address.Bytes())
-	return calculateCreate2Address(proxyFactory, salt, common.HexToHash(PROXYInitCodeHashHex))
+	return deriveProxyWallet(address, proxyFactory, common.HexToHash(PROXYInitCodeHashHex))
 }
 
 func DeriveSafe(address, safeFactory common.Address) common.Address {
+	return deriveSafe(address, safeFactory, common.HexToHash(SAFEInitCodeHash))
+}
+
+func deriveProxyWallet(address, proxyFactory common.Address, initCodeHash common.Hash) common.Address {
+	salt := crypto.Keccak256Hash(address.Bytes())
+	return calculateCreate2Address(proxyFactory, salt, initCodeHash)
+}
+
+func deriveSafe(address, safeFactory common.Address, initCodeHash common.Hash) common.Address {
 	result, _ := encodeAbiParameters(
 		[]string{"address"},
 		[]any{
@@ -37,7 +45,7 @@ func DeriveSafe(address, safeFactory common.Address) common.Address {
 	)
 
 	salt := crypto.Keccak256Hash(result)
-	return calculateCreate2Address(safeFactory, salt, common.HexToHash(SAFEInitCodeHash))
+	return calculateCreate2Address(safeFactory, salt, initCodeHash)
 }
 
 func calculateCreate2Address(from common.Address, salt, initCodeHash common.Hash) common.Address {
@@ -0,0 +1,90 @@
+package relayer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer signs relayer requests on behalf of the wallet owner. Signatures
+// are 65 bytes [R || S || V] with V in {27, 28}.
+type Signer interface {
+	// Address returns the owner (EOA) address.
+	Address() common.Address
+	// SignHash signs a 32-byte digest as an EIP-191 personal message, the
+	// way eth_sign and personal_sign do. Safe and proxy struct hashes are
+	// signed this way.
+	SignHash(hash []byte) ([]byte, error)
+	// SignTypedData signs the EIP-712 digest of typedData.
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+}
+
+// Signers holding a connection or other resources also implement io.Closer;
+// Client.Close releases them.
+
+// PrivateKeySigner signs with an in-memory ECDSA key.
+type PrivateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewHexKeySigner parses a hex encoded private key, with or without 0x
+// prefix.
+func NewHexKeySigner(privateKeyHex string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return NewPrivateKeySigner(key), nil
+}
+
+// NewKeystoreSigner decrypts a go-ethereum (v3) keystore JSON file.
+func NewKeystoreSigner(keyJSON []byte, passphrase string) (*PrivateKeySigner, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *PrivateKeySigner) SignHash(hash []byte) ([]byte, error) {
+	return s.sign(accounts.TextHash(hash))
+}
+
+func (s *PrivateKeySigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sign(digest)
+}
+
+func (s *PrivateKeySigner) sign(digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+
+	return sig, nil
+}
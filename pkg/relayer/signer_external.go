@@ -0,0 +1,95 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ExternalSigner delegates signing to an external signer speaking the Clef
+// JSON-RPC API (account_list, account_signData, account_signTypedData). The
+// private key never leaves the signer process.
+type ExternalSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewExternalSigner connects to endpoint. When address is the zero address
+// the first account reported by the signer is used.
+func NewExternalSigner(endpoint string, address common.Address) (*ExternalSigner, error) {
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to external signer: %w", err)
+	}
+
+	if address == (common.Address{}) {
+		var addresses []common.Address
+		if err := client.Call(&addresses, "account_list"); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to list external signer accounts: %w", err)
+		}
+		if len(addresses) == 0 {
+			client.Close()
+			return nil, errors.New("external signer has no accounts")
+		}
+		address = addresses[0]
+	}
+
+	return &ExternalSigner{
+		client:  client,
+		address: address,
+	}, nil
+}
+
+// Close disconnects from the external signer. It always returns nil.
+func (s *ExternalSigner) Close() error {
+	s.client.Close()
+	return nil
+}
+
+func (s *ExternalSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *ExternalSigner) SignHash(hash []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	signAddress := common.NewMixedcaseAddress(s.address)
+	if err := s.client.Call(&sig, "account_signData",
+		accounts.MimetypeTextPlain,
+		&signAddress,
+		hexutil.Encode(hash),
+	); err != nil {
+		return nil, fmt.Errorf("external signer failed to sign: %w", err)
+	}
+
+	return normalizeExternalSignature(sig)
+}
+
+func (s *ExternalSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	var sig hexutil.Bytes
+	signAddress := common.NewMixedcaseAddress(s.address)
+	if err := s.client.Call(&sig, "account_signTypedData",
+		&signAddress,
+		typedData,
+	); err != nil {
+		return nil, fmt.Errorf("external signer failed to sign typed data: %w", err)
+	}
+
+	return normalizeExternalSignature(sig)
+}
+
+func normalizeExternalSignature(sig []byte) ([]byte, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length from external signer: %d", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+
+	return sig, nil
+}
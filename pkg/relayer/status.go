@@ -0,0 +1,134 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"polymarket-cli/pkg/apierror"
+)
+
+type RelayerTxState string
+
+const (
+	RelayerTxStateNew       RelayerTxState = "STATE_NEW"
+	RelayerTxStateExecuted  RelayerTxState = "STATE_EXECUTED"
+	RelayerTxStateMined     RelayerTxState = "STATE_MINED"
+	RelayerTxStateConfirmed RelayerTxState = "STATE_CONFIRMED"
+	RelayerTxStateFailed    RelayerTxState = "STATE_FAILED"
+	RelayerTxStateInvalid   RelayerTxState = "STATE_INVALID"
+)
+
+// IsTerminal reports whether the relayer will no longer update a
+// transaction in this state.
+func (s RelayerTxState) IsTerminal() bool {
+	switch s {
+	case RelayerTxStateMined, RelayerTxStateConfirmed, RelayerTxStateFailed, RelayerTxStateInvalid:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSuccess reports whether the transaction made it on chain.
+func (s RelayerTxState) IsSuccess() bool {
+	return s == RelayerTxStateMined || s == RelayerTxStateConfirmed
+}
+
+type RelayerTransaction struct {
+	TransactionID   string         `json:"transactionID"`
+	TransactionHash string         `json:"transactionHash"`
+	From            string         `json:"from"`
+	To              string         `json:"to"`
+	ProxyAddress    string         `json:"proxyAddress"`
+	Data            string         `json:"data"`
+	Nonce           string         `json:"nonce"`
+	Value           string         `json:"value"`
+	State           RelayerTxState `json:"state"`
+	Type            string         `json:"type"`
+	Metadata        string         `json:"metadata"`
+	CreatedAt       string         `json:"createdAt"`
+	UpdatedAt       string         `json:"updatedAt"`
+}
+
+const (
+	pollInitialInterval = time.Second
+	pollMaxInterval     = 10 * time.Second
+)
+
+func (c *Client) GetTransaction(id string) (*RelayerTransaction, error) {
+	return c.GetTransactionContext(context.Background(), id)
+}
+
+func (c *Client) GetTransactionContext(ctx context.Context, id string) (*RelayerTransaction, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"transaction", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("id", id)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apierror.New(resp)
+	}
+
+	var result []RelayerTransaction
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("transaction %s not found", id)
+	}
+
+	return &result[0], nil
+}
+
+// WaitForState polls the relayer until the transaction reaches a terminal
+// state or the timeout expires. The last seen transaction is returned along
+// with an error when it failed, was rejected as invalid, or timed out.
+func (c *Client) WaitForState(id string, timeout time.Duration) (*RelayerTransaction, error) {
+	return c.WaitForStateContext(context.Background(), id, timeout)
+}
+
+// WaitForStateContext is WaitForState that also stops when ctx is done.
+func (c *Client) WaitForStateContext(ctx context.Context, id string, timeout time.Duration) (*RelayerTransaction, error) {
+	deadline := time.Now().Add(timeout)
+	interval := pollInitialInterval
+
+	for {
+		tx, err := c.GetTransactionContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if tx.State.IsTerminal() {
+			if !tx.State.IsSuccess() {
+				return tx, fmt.Errorf("transaction %s ended in state %s", id, tx.State)
+			}
+			return tx, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return tx, fmt.Errorf("timed out waiting for transaction %s (last state %s)", id, tx.State)
+		}
+
+		select {
+		case <-ctx.Done():
+			return tx, ctx.Err()
+		case <-time.After(min(interval, remaining)):
+		}
+		interval = min(interval*3/2, pollMaxInterval)
+	}
+}
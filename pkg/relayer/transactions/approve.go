@@ -0,0 +1,111 @@
+package transactions
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const approveABI = `
+[{
+  "name": "approve",
+  "type": "function",
+  "inputs": [
+    { "name": "spender", "type": "address" },
+    { "name": "amount", "type": "uint256" }
+  ],
+  "outputs": [
+    { "name": "", "type": "bool" }
+  ]
+}]
+`
+
+const setApprovalForAllABI = `
+[{
+  "name": "setApprovalForAll",
+  "type": "function",
+  "inputs": [
+    { "name": "operator", "type": "address" },
+    { "name": "approved", "type": "bool" }
+  ],
+  "outputs": []
+}]
+`
+
+type ApproveParams struct {
+	Token   common.Address
+	Spender common.Address
+	Amount  *big.Int
+}
+
+type SetApprovalForAllParams struct {
+	Token    common.Address
+	Operator common.Address
+	Approved bool
+}
+
+// BuildApproveTransaction builds an ERC20 approve(spender, amount) call.
+func BuildApproveTransaction(params ApproveParams) (*Transaction, error) {
+	data, err := encodeApprove(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode approve: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.Token,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func encodeApprove(params ApproveParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(approveABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("approve",
+		params.Spender,
+		params.Amount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
+
+// BuildSetApprovalForAllTransaction builds an ERC1155
+// setApprovalForAll(operator, approved) call.
+func BuildSetApprovalForAllTransaction(params SetApprovalForAllParams) (*Transaction, error) {
+	data, err := encodeSetApprovalForAll(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode set approval for all: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.Token,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func encodeSetApprovalForAll(params SetApprovalForAllParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(setApprovalForAllABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("setApprovalForAll",
+		params.Operator,
+		params.Approved,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
@@ -19,6 +19,19 @@ type SafeTransaction struct {
 	Value     *big.Int       `json:"value"`
 }
 
+const (
+	ProxyCallTypeInvalid uint8 = iota
+	ProxyCallTypeCall
+	ProxyCallTypeDelegateCall
+)
+
+type ProxyTransaction struct {
+	TypeCode uint8          `json:"typeCode"`
+	To       common.Address `json:"to"`
+	Value    *big.Int       `json:"value"`
+	Data     []byte         `json:"data"`
+}
+
 type SignatureParams struct {
 	GasPrice        *string `json:"gasPrice,omitempty"`
 	RelayerFee      *string `json:"relayerFee,omitempty"`
@@ -0,0 +1,92 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// knownABIs lists every call this package can build, so calldata produced by
+// the builders can be decoded back for inspection.
+var knownABIs = []string{
+	redeemPositionsABI,
+	negRiskRedeemPositionsABI,
+	mergePositionsABI,
+	negRiskMergePositionsABI,
+	splitPositionABI,
+	negRiskSplitPositionABI,
+	approveABI,
+	setApprovalForAllABI,
+}
+
+var ErrUnknownMethod = errors.New("unknown method selector")
+
+type DecodedCall struct {
+	Method    string         `json:"method"`
+	Signature string         `json:"signature"`
+	Args      map[string]any `json:"args"`
+}
+
+// DecodeCalldata decodes calldata produced by one of the builders in this
+// package. Arguments are converted to JSON friendly values: addresses,
+// hashes and byte strings as hex, integers as decimal strings.
+func DecodeCalldata(data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata too short: %d bytes", len(data))
+	}
+
+	for _, contractABI := range knownABIs {
+		parsedABI, err := abi.JSON(strings.NewReader(contractABI))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI: %w", err)
+		}
+
+		method, err := parsedABI.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+
+		args := make(map[string]any)
+		if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+			return nil, fmt.Errorf("failed to unpack %s arguments: %w", method.Name, err)
+		}
+
+		for name, value := range args {
+			args[name] = formatArg(value)
+		}
+
+		return &DecodedCall{
+			Method:    method.Name,
+			Signature: method.Sig,
+			Args:      args,
+		}, nil
+	}
+
+	return nil, ErrUnknownMethod
+}
+
+func formatArg(value any) any {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	case []*big.Int:
+		values := make([]string, len(v))
+		for i, n := range v {
+			values[i] = n.String()
+		}
+		return values
+	default:
+		return v
+	}
+}
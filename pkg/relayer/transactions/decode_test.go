@@ -0,0 +1,208 @@
+package transactions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	testCTF       = common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045")
+	testUSDC      = common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174")
+	testAdapter   = common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296")
+	testCondition = common.HexToHash("0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1")
+	testParent    = common.HexToHash("0x01")
+)
+
+func TestBuildersDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		build     func() (*Transaction, error)
+		to        common.Address
+		signature string
+		args      map[string]any
+	}{
+		{
+			name: "approve",
+			build: func() (*Transaction, error) {
+				return BuildApproveTransaction(ApproveParams{Token: testUSDC, Spender: testCTF, Amount: math.MaxBig256})
+			},
+			to:        testUSDC,
+			signature: "approve(address,uint256)",
+			args: map[string]any{
+				"spender": testCTF.Hex(),
+				"amount":  math.MaxBig256.String(),
+			},
+		},
+		{
+			name: "setApprovalForAll",
+			build: func() (*Transaction, error) {
+				return BuildSetApprovalForAllTransaction(SetApprovalForAllParams{Token: testCTF, Operator: testAdapter, Approved: true})
+			},
+			to:        testCTF,
+			signature: "setApprovalForAll(address,bool)",
+			args: map[string]any{
+				"operator": testAdapter.Hex(),
+				"approved": true,
+			},
+		},
+		{
+			name: "redeemPositions",
+			build: func() (*Transaction, error) {
+				return BuildRedeemTransaction(RedeemParams{
+					ConditionalTokens:  testCTF,
+					CollateralToken:    testUSDC,
+					ParentCollectionID: testParent,
+					ConditionID:        testCondition,
+					IndexSets:          []*big.Int{big.NewInt(1), big.NewInt(2)},
+				})
+			},
+			to:        testCTF,
+			signature: "redeemPositions(address,bytes32,bytes32,uint256[])",
+			args: map[string]any{
+				"collateralToken":    testUSDC.Hex(),
+				"parentCollectionId": testParent.Hex(),
+				"conditionId":        testCondition.Hex(),
+				"indexSets":          []string{"1", "2"},
+			},
+		},
+		{
+			name: "neg-risk redeemPositions",
+			build: func() (*Transaction, error) {
+				return BuildNegRiskRedeemTransaction(NegRiskRedeemParams{
+					NegRiskAdapter: testAdapter,
+					ConditionID:    testCondition,
+					Amounts:        []*big.Int{big.NewInt(1_999_999), big.NewInt(0)},
+				})
+			},
+			to:        testAdapter,
+			signature: "redeemPositions(bytes32,uint256[])",
+			args: map[string]any{
+				"_conditionId": testCondition.Hex(),
+				"_amounts":     []string{"1999999", "0"},
+			},
+		},
+		{
+			name: "mergePositions",
+			build: func() (*Transaction, error) {
+				return BuildMergeTransaction(MergeParams{
+					ConditionalTokens: testCTF,
+					CollateralToken:   testUSDC,
+					ConditionID:       testCondition,
+					Partition:         []*big.Int{big.NewInt(1), big.NewInt(2)},
+					Amount:            big.NewInt(2_500_000),
+				})
+			},
+			to:        testCTF,
+			signature: "mergePositions(address,bytes32,bytes32,uint256[],uint256)",
+			args: map[string]any{
+				"collateralToken":    testUSDC.Hex(),
+				"parentCollectionId": common.Hash{}.Hex(),
+				"conditionId":        testCondition.Hex(),
+				"partition":          []string{"1", "2"},
+				"amount":             "2500000",
+			},
+		},
+		{
+			name: "neg-risk mergePositions",
+			build: func() (*Transaction, error) {
+				return BuildNegRiskMergeTransaction(NegRiskMergeParams{
+					NegRiskAdapter: testAdapter,
+					ConditionID:    testCondition,
+					Amount:         big.NewInt(2_500_000),
+				})
+			},
+			to:        testAdapter,
+			signature: "mergePositions(bytes32,uint256)",
+			args: map[string]any{
+				"_conditionId": testCondition.Hex(),
+				"_amount":      "2500000",
+			},
+		},
+		{
+			name: "splitPosition",
+			build: func() (*Transaction, error) {
+				return BuildSplitTransaction(SplitParams{
+					ConditionalTokens: testCTF,
+					CollateralToken:   testUSDC,
+					ConditionID:       testCondition,
+					Partition:         []*big.Int{big.NewInt(1), big.NewInt(2)},
+					Amount:            big.NewInt(1_500_000),
+				})
+			},
+			to:        testCTF,
+			signature: "splitPosition(address,bytes32,bytes32,uint256[],uint256)",
+			args: map[string]any{
+				"collateralToken":    testUSDC.Hex(),
+				"parentCollectionId": common.Hash{}.Hex(),
+				"conditionId":        testCondition.Hex(),
+				"partition":          []string{"1", "2"},
+				"amount":             "1500000",
+			},
+		},
+		{
+			name: "neg-risk splitPosition",
+			build: func() (*Transaction, error) {
+				return BuildNegRiskSplitTransaction(NegRiskSplitParams{
+					NegRiskAdapter: testAdapter,
+					ConditionID:    testCondition,
+					Amount:         big.NewInt(1_500_000),
+				})
+			},
+			to:        testAdapter,
+			signature: "splitPosition(bytes32,uint256)",
+			args: map[string]any{
+				"_conditionId": testCondition.Hex(),
+				"_amount":      "1500000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tt.build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tx.To != tt.to {
+				t.Errorf("to = %s, want %s", tx.To.Hex(), tt.to.Hex())
+			}
+			if tx.Value.Sign() != 0 {
+				t.Errorf("value = %s, want 0", tx.Value)
+			}
+
+			selector := crypto.Keccak256([]byte(tt.signature))[:4]
+			if !bytes.Equal(tx.Data[:4], selector) {
+				t.Errorf("selector = %x, want %x (%s)", tx.Data[:4], selector, tt.signature)
+			}
+
+			decoded, err := DecodeCalldata(tx.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Signature != tt.signature {
+				t.Errorf("decoded signature = %s, want %s", decoded.Signature, tt.signature)
+			}
+			if !reflect.DeepEqual(decoded.Args, tt.args) {
+				t.Errorf("decoded args = %#v, want %#v", decoded.Args, tt.args)
+			}
+		})
+	}
+}
+
+func TestDecodeCalldataUnknown(t *testing.T) {
+	if _, err := DecodeCalldata([]byte{0xde, 0xad, 0xbe, 0xef}); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("err = %v, want ErrUnknownMethod", err)
+	}
+
+	if _, err := DecodeCalldata([]byte{0x01}); err == nil {
+		t.Error("short calldata decoded without error")
+	}
+}
@@ -0,0 +1,115 @@
+package transactions
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const mergePositionsABI = `
+[{
+  "name": "mergePositions",
+  "type": "function",
+  "inputs": [
+    { "name": "collateralToken", "type": "address" },
+    { "name": "parentCollectionId", "type": "bytes32" },
+    { "name": "conditionId", "type": "bytes32" },
+    { "name": "partition", "type": "uint256[]" },
+    { "name": "amount", "type": "uint256" }
+  ],
+  "outputs": []
+}]
+`
+
+const negRiskMergePositionsABI = `
+[{
+  "name": "mergePositions",
+  "type": "function",
+  "inputs": [
+    { "name": "_conditionId", "type": "bytes32" },
+    { "name": "_amount", "type": "uint256" }
+  ],
+  "outputs": []
+}]
+`
+
+type MergeParams struct {
+	ConditionalTokens  common.Address
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	Partition          []*big.Int
+	Amount             *big.Int
+}
+
+type NegRiskMergeParams struct {
+	NegRiskAdapter common.Address
+	ConditionID    common.Hash
+	Amount         *big.Int
+}
+
+func BuildMergeTransaction(params MergeParams) (*Transaction, error) {
+	data, err := encodeMergePositions(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merge positions: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.ConditionalTokens,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func BuildNegRiskMergeTransaction(params NegRiskMergeParams) (*Transaction, error) {
+	data, err := encodeNegRiskMergePositions(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode neg risk merge positions: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.NegRiskAdapter,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func encodeMergePositions(params MergeParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(mergePositionsABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("mergePositions",
+		params.CollateralToken,
+		params.ParentCollectionID,
+		params.ConditionID,
+		params.Partition,
+		params.Amount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
+
+func encodeNegRiskMergePositions(params NegRiskMergeParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(negRiskMergePositionsABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("mergePositions",
+		params.ConditionID,
+		params.Amount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
@@ -9,6 +9,32 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const redeemPositionsABI = `
+[{
+  "name": "redeemPositions",
+  "type": "function",
+  "inputs": [
+    { "name": "collateralToken", "type": "address" },
+    { "name": "parentCollectionId", "type": "bytes32" },
+    { "name": "conditionId", "type": "bytes32" },
+    { "name": "indexSets", "type": "uint256[]" }
+  ],
+  "outputs": []
+}]
+`
+
+const negRiskRedeemPositionsABI = `
+[{
+  "name": "redeemPositions",
+  "type": "function",
+  "inputs": [
+    { "name": "_conditionId", "type": "bytes32" },
+    { "name": "_amounts", "type": "uint256[]" }
+  ],
+  "outputs": []
+}]
+`
+
 type RedeemParams struct {
 	ConditionalTokens  common.Address
 	CollateralToken    common.Address
@@ -17,6 +43,12 @@ type RedeemParams struct {
 	IndexSets          []*big.Int
 }
 
+type NegRiskRedeemParams struct {
+	NegRiskAdapter common.Address
+	ConditionID    common.Hash
+	Amounts        []*big.Int
+}
+
 func BuildRedeemTransaction(params RedeemParams) (*Transaction, error) {
 	data, err := encodeRedeemPositions(params)
 	if err != nil {
@@ -31,21 +63,7 @@ func BuildRedeemTransaction(params RedeemParams) (*Transaction, error) {
 }
 
 func encodeRedeemPositions(params RedeemParams) ([]byte, error) {
-	redeemABI := `
-	[{
-      "name": "redeemPositions",
-      "type": "function",
-      "inputs": [
-        { "name": "collateralToken", "type": "address" },
-        { "name": "parentCollectionId", "type": "bytes32" },
-        { "name": "conditionId", "type": "bytes32" },
-        { "name": "indexSets", "type": "uint256[]" }
-      ],
-      "outputs": []
-    }]
-	`
-
-	parsedABI, err := abi.JSON(strings.NewReader(redeemABI))
+	parsedABI, err := abi.JSON(strings.NewReader(redeemPositionsABI))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ABI: %w", err)
 	}
@@ -62,3 +80,33 @@ func encodeRedeemPositions(params RedeemParams) ([]byte, error) {
 
 	return data, nil
 }
+
+func BuildNegRiskRedeemTransaction(params NegRiskRedeemParams) (*Transaction, error) {
+	data, err := encodeNegRiskRedeemPositions(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode neg risk redeem positions: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.NegRiskAdapter,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func encodeNegRiskRedeemPositions(params NegRiskRedeemParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(negRiskRedeemPositionsABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("redeemPositions",
+		params.ConditionID,
+		params.Amounts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
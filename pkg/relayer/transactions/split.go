@@ -0,0 +1,115 @@
+package transactions
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const splitPositionABI = `
+[{
+  "name": "splitPosition",
+  "type": "function",
+  "inputs": [
+    { "name": "collateralToken", "type": "address" },
+    { "name": "parentCollectionId", "type": "bytes32" },
+    { "name": "conditionId", "type": "bytes32" },
+    { "name": "partition", "type": "uint256[]" },
+    { "name": "amount", "type": "uint256" }
+  ],
+  "outputs": []
+}]
+`
+
+const negRiskSplitPositionABI = `
+[{
+  "name": "splitPosition",
+  "type": "function",
+  "inputs": [
+    { "name": "_conditionId", "type": "bytes32" },
+    { "name": "_amount", "type": "uint256" }
+  ],
+  "outputs": []
+}]
+`
+
+type SplitParams struct {
+	ConditionalTokens  common.Address
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	Partition          []*big.Int
+	Amount             *big.Int
+}
+
+type NegRiskSplitParams struct {
+	NegRiskAdapter common.Address
+	ConditionID    common.Hash
+	Amount         *big.Int
+}
+
+func BuildSplitTransaction(params SplitParams) (*Transaction, error) {
+	data, err := encodeSplitPosition(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode split position: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.ConditionalTokens,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func BuildNegRiskSplitTransaction(params NegRiskSplitParams) (*Transaction, error) {
+	data, err := encodeNegRiskSplitPosition(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode neg risk split position: %w", err)
+	}
+
+	return &Transaction{
+		To:    params.NegRiskAdapter,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+func encodeSplitPosition(params SplitParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(splitPositionABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("splitPosition",
+		params.CollateralToken,
+		params.ParentCollectionID,
+		params.ConditionID,
+		params.Partition,
+		params.Amount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}
+
+func encodeNegRiskSplitPosition(params NegRiskSplitParams) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(negRiskSplitPositionABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	data, err := parsedABI.Pack("splitPosition",
+		params.ConditionID,
+		params.Amount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments: %w", err)
+	}
+
+	return data, nil
+}